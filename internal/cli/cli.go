@@ -0,0 +1,240 @@
+// Package cli runs the checker, either as a Drone or Woodpecker plugin
+// configured through the environment or from the command line against any
+// pull request.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/config"
+	"github.com/nyambati/drone-pr-checker/internal/github"
+	"github.com/nyambati/drone-pr-checker/internal/plugin"
+	"github.com/nyambati/drone-pr-checker/internal/tracker"
+	"gopkg.in/yaml.v3"
+)
+
+const usage = `Usage:
+  drone-pr-checker [--explain]              run as a Drone or Woodpecker plugin
+  drone-pr-checker check [flags] URL        check a pull request
+  drone-pr-checker explain [flags] [URL]    explain the configuration and run the checks without failing
+  drone-pr-checker validate-config [flags]  validate the settings and policy file
+  drone-pr-checker hook commit-msg [flags] FILE
+                                            check the subject of a commit message
+  drone-pr-checker hook pre-push [flags]    check the names of the pushed branches
+  drone-pr-checker install-hooks [flags]    install the commit-msg and pre-push hooks
+
+Settings are read from the same PLUGIN_* variables and policy file as in Drone.
+`
+
+// newGitHub returns the GitHub client, replaced in tests.
+var newGitHub = github.New
+
+// Run runs the command given by args, without the program name, and writes
+// its output to stdout. It returns flag.ErrHelp after printing the usage of
+// a command on request.
+func Run(args []string, stdout io.Writer) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runPlugin(args, stdout)
+	}
+
+	switch args[0] {
+	case "check":
+		return runCheck(args[1:], stdout, false)
+	case "explain":
+		return runCheck(args[1:], stdout, true)
+	case "validate-config":
+		return runValidateConfig(args[1:], stdout)
+	case "hook":
+		return runHook(args[1:], stdout)
+	case "install-hooks":
+		return runInstallHooks(args[1:], stdout)
+	case "help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", args[0], usage)
+	}
+}
+
+// runPlugin runs the checks on the pull request of the Drone pipeline.
+func runPlugin(args []string, stdout io.Writer) error {
+	flags := newFlagSet("drone-pr-checker", stdout)
+	explain := flags.Bool("explain", false, "print the effective configuration and run the checks without failing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts := []config.Option{}
+	if *explain {
+		opts = append(opts, config.DryRun())
+	}
+
+	cfg, err := config.New(opts...)
+	if err != nil {
+		return err
+	}
+
+	return report(cfg, newGitHub(cfg.Github.Token), stdout)
+}
+
+// runCheck runs the checks on the pull request at the URL given in args.
+// Without a URL, explain falls back to the pull request of the pipeline.
+func runCheck(args []string, stdout io.Writer, explain bool) error {
+	name := "check"
+	if explain {
+		name = "explain"
+	}
+
+	flags := newFlagSet(name, stdout)
+	token := flags.String("token", os.Getenv("GITHUB_TOKEN"), "GitHub token, defaults to GITHUB_TOKEN")
+	policy := flags.String("policy", "", "policy file, defaults to PLUGIN_POLICY")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts := []config.Option{config.WithGitHubToken(*token)}
+	if explain {
+		opts = append(opts, config.DryRun())
+	}
+	if *policy != "" {
+		opts = append(opts, config.WithPolicy(*policy))
+	}
+
+	client := newGitHub(*token)
+
+	switch flags.NArg() {
+	case 0:
+		if !explain {
+			return fmt.Errorf("check needs a pull request URL\n\n%s", usage)
+		}
+	case 1:
+		pr, err := ParsePullRequestURL(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		if pr, err = describe(client, pr); err != nil {
+			return err
+		}
+		opts = append(opts, config.WithPullRequest(pr))
+	default:
+		return fmt.Errorf("%s takes a single pull request URL\n\n%s", name, usage)
+	}
+
+	cfg, err := config.New(opts...)
+	if err != nil {
+		return err
+	}
+
+	return report(cfg, client, stdout)
+}
+
+// runValidateConfig loads the settings and policy file and reports whether
+// they are valid, without needing a pull request.
+func runValidateConfig(args []string, stdout io.Writer) error {
+	flags := newFlagSet("validate-config", stdout)
+	policy := flags.String("policy", "", "policy file, defaults to PLUGIN_POLICY")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts := []config.Option{config.SettingsOnly()}
+	if *policy != "" {
+		opts = append(opts, config.WithPolicy(*policy))
+	}
+
+	cfg, err := config.New(opts...)
+	if err != nil {
+		return err
+	}
+
+	if err := printPolicy(cfg, stdout); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, "✅ configuration is valid")
+	return nil
+}
+
+// ParsePullRequestURL returns the owner, repository and number of a pull
+// request URL such as https://github.com/org/repo/pull/123.
+func ParsePullRequestURL(raw string) (config.PullRequest, error) {
+	invalid := fmt.Errorf("%q is not a GitHub pull request URL", raw)
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Host != "github.com" {
+		return config.PullRequest{}, invalid
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] == "" || parts[1] == "" || parts[2] != "pull" {
+		return config.PullRequest{}, invalid
+	}
+
+	number, err := strconv.Atoi(parts[3])
+	if err != nil || number <= 0 {
+		return config.PullRequest{}, invalid
+	}
+
+	return config.PullRequest{Owner: parts[0], Repo: parts[1], Number: number}, nil
+}
+
+// describe completes pr with the title and branches Drone would pass.
+func describe(client github.GitHubInterface, pr config.PullRequest) (config.PullRequest, error) {
+	details, err := client.GetPullRequest(pr.Owner, pr.Repo, pr.Number)
+	if err != nil {
+		return pr, fmt.Errorf("fetching %s/%s#%d: %w", pr.Owner, pr.Repo, pr.Number, err)
+	}
+
+	pr.Title = details.GetTitle()
+	pr.SourceBranch = details.GetHead().GetRef()
+	pr.TargetBranch = details.GetBase().GetRef()
+	return pr, nil
+}
+
+// report prints the configuration that applies and runs the checks. It
+// returns an error when any check failed.
+func report(cfg *config.Config, client github.GitHubInterface, stdout io.Writer) error {
+	if err := printPolicy(cfg, stdout); err != nil {
+		return err
+	}
+
+	if len(cfg.Profiles) > 0 {
+		fmt.Fprintf(stdout, "Target branch profiles: %s\n", strings.Join(cfg.Profiles, ", "))
+	}
+
+	if cfg.Settings.DryRun {
+		cfg.Explain(stdout)
+	}
+
+	var issues tracker.TrackerInterface
+	if cfg.Tracker.URL != "" {
+		issues = tracker.New(cfg.Tracker.URL, cfg.Tracker.Token, nil)
+	}
+
+	checker := plugin.New(cfg.Settings, client, issues)
+	return checker.Report(stdout)
+}
+
+func printPolicy(cfg *config.Config, stdout io.Writer) error {
+	if len(cfg.Policy) == 0 {
+		return nil
+	}
+
+	policy, err := yaml.Marshal(cfg.Policy)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Effective policy:\n%s\n", policy)
+	return nil
+}
+
+func newFlagSet(name string, output io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(output)
+	return flags
+}
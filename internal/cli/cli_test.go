@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	gh "github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+	"github.com/nyambati/drone-pr-checker/internal/github"
+)
+
+// TestGithubClient serves a single pull request, the other methods are not
+// used by title checks.
+type TestGithubClient struct {
+	github.GitHubInterface
+	pr *gh.PullRequest
+}
+
+func (t *TestGithubClient) GetPullRequest(owner string, repo string, number int) (*gh.PullRequest, error) {
+	return t.pr, nil
+}
+
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, variable := range os.Environ() {
+		name, _, _ := strings.Cut(variable, "=")
+		if strings.HasPrefix(name, "PLUGIN_") || strings.HasPrefix(name, "DRONE_") || name == "GITHUB_TOKEN" {
+			t.Setenv(name, "")
+		}
+	}
+}
+
+func TestParsePullRequestURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    config.PullRequest
+		wantErr bool
+	}{
+		{
+			name: "ParsePullRequestURL",
+			raw:  "https://github.com/octocat/hello-world/pull/123",
+			want: config.PullRequest{Owner: "octocat", Repo: "hello-world", Number: 123},
+		},
+		{
+			name: "ParsePullRequestURLFilesTab",
+			raw:  "https://github.com/octocat/hello-world/pull/7/files",
+			want: config.PullRequest{Owner: "octocat", Repo: "hello-world", Number: 7},
+		},
+		{name: "ParsePullRequestURLIssue", raw: "https://github.com/octocat/hello-world/issues/123", wantErr: true},
+		{name: "ParsePullRequestURLNoNumber", raw: "https://github.com/octocat/hello-world/pull/new", wantErr: true},
+		{name: "ParsePullRequestURLOtherHost", raw: "https://gitlab.com/octocat/hello-world/pull/1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePullRequestURL(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePullRequestURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePullRequestURL() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	policy := filepath.Join(dir, "policy.yml")
+	if err := os.WriteFile(policy, []byte("prefixes: [feat:, fix:]\nprofiles:\n  release/*:\n    prefixes: [fix:]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	strict := filepath.Join(dir, "strict.yml")
+	if err := os.WriteFile(strict, []byte("regexp: ^feat\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "invalid.yml")
+	if err := os.WriteFile(invalid, []byte("draft: ignore\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	restore := newGitHub
+	newGitHub = func(token string) github.GitHubInterface {
+		return &TestGithubClient{pr: &gh.PullRequest{
+			Title: gh.String("fix: handle empty bodies"),
+			Head:  &gh.PullRequestBranch{Ref: gh.String("fix/empty-bodies")},
+			Base:  &gh.PullRequestBranch{Ref: gh.String("release/1.0")},
+		}}
+	}
+	t.Cleanup(func() { newGitHub = restore })
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "RunExplainPullRequest",
+			args: []string{"explain", "-policy", policy, "https://github.com/octocat/hello-world/pull/42"},
+			want: []string{
+				"Target branch profiles: release/*\n",
+				"⚙️ setting=prefixes env=PLUGIN_PREFIXES source=profile release/* value=[\"fix:\"]\n",
+				"⚙️ setting=DRONE_PULL_REQUEST_TITLE env=DRONE_PULL_REQUEST_TITLE source=flag value=fix: handle empty bodies\n",
+				"⚙️ setting=DRONE_PULL_REQUEST env=DRONE_PULL_REQUEST source=flag value=42\n",
+			},
+		},
+		{
+			name: "RunCheckPullRequest",
+			args: []string{"check", "-policy", policy, "https://github.com/octocat/hello-world/pull/42"},
+			want: []string{"✅ step=prefix message=prefixes check passed\n"},
+		},
+		{
+			name:    "RunCheckPullRequestFailing",
+			args:    []string{"check", "-policy", strict, "https://github.com/octocat/hello-world/pull/42"},
+			want:    []string{"❌ step=regexp message="},
+			wantErr: "found 1 errors",
+		},
+		{
+			name: "RunExplainPullRequestFailing",
+			args: []string{"explain", "-policy", strict, "https://github.com/octocat/hello-world/pull/42"},
+			want: []string{"❌ step=regexp message=", "Dry run, found 1 errors\n"},
+		},
+		{
+			name: "RunExplainInvalidConfig",
+			args: []string{"explain", "-policy", invalid, "https://github.com/octocat/hello-world/pull/42"},
+			want: []string{
+				"❌ config=PLUGIN_DRAFT must be one of run, skip, fail, warn, got \"ignore\"\n",
+				"Dry run, found 0 errors\n",
+			},
+		},
+		{
+			name: "RunValidateConfig",
+			args: []string{"validate-config", "-policy", policy},
+			want: []string{"✅ configuration is valid\n"},
+		},
+		{
+			name:    "RunValidateConfigInvalid",
+			args:    []string{"validate-config", "-policy", invalid},
+			wantErr: `PLUGIN_DRAFT must be one of run, skip, fail, warn, got "ignore"`,
+		},
+		{
+			name:    "RunCheckWithoutURL",
+			args:    []string{"check"},
+			wantErr: "check needs a pull request URL",
+		},
+		{
+			name:    "RunUnknownCommand",
+			args:    []string{"lint"},
+			wantErr: `unknown command "lint"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+
+			var out bytes.Buffer
+			err := Run(tt.args, &out)
+			if tt.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Fatalf("Run() error = %v, want %s", err, tt.wantErr)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			for _, line := range tt.want {
+				if !strings.Contains(out.String(), line) {
+					t.Errorf("Run() output does not contain %q:\n%s", line, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/config"
+	"github.com/nyambati/drone-pr-checker/internal/plugin"
+)
+
+// hookMarker identifies hook scripts written by install-hooks, which may be
+// replaced without -force.
+const hookMarker = "# Installed by drone-pr-checker install-hooks."
+
+// hooks are the git hooks install-hooks writes.
+var hooks = []string{"commit-msg", "pre-push"}
+
+// stdin is where the pre-push hook reads the pushed refs, replaced in tests.
+var stdin io.Reader = os.Stdin
+
+// git runs git with args and returns its trimmed output, replaced in tests.
+var git = func(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// runHook runs the offline checks of a git hook: the title checks on the
+// subject of a commit message for commit-msg and the branch name check on
+// every pushed branch for pre-push.
+func runHook(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("hook needs the name of the git hook\n\n%s", usage)
+	}
+	name, args := args[0], args[1:]
+
+	flags := newFlagSet("hook "+name, stdout)
+	policy := flags.String("policy", "", "policy file, defaults to PLUGIN_POLICY")
+	target := flags.String("target", "", "target branch selecting the policy profile")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	pr := config.PullRequest{TargetBranch: *target}
+	pullRequests := []config.PullRequest{}
+	ids := []string{}
+
+	switch name {
+	case "commit-msg":
+		if flags.NArg() != 1 {
+			return fmt.Errorf("the commit-msg hook needs the commit message file")
+		}
+		message, err := os.ReadFile(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		pr.Title = subject(string(message))
+		if pr.Title == "" || generated(pr.Title) {
+			return nil
+		}
+		pullRequests = append(pullRequests, pr)
+		ids = append(ids, plugin.PrefixStepID, plugin.TypeStepID, plugin.RegexpStepID)
+	case "pre-push":
+		branches, err := pushedBranches(stdin)
+		if err != nil {
+			return err
+		}
+		for _, branch := range branches {
+			pr.SourceBranch = branch
+			pullRequests = append(pullRequests, pr)
+		}
+		ids = append(ids, plugin.BranchStepID)
+	default:
+		return fmt.Errorf("unknown git hook %q, want one of %s", name, strings.Join(hooks, ", "))
+	}
+
+	errs := []error{}
+	for _, pr := range pullRequests {
+		opts := []config.Option{config.Offline(), config.SettingsOnly(), config.WithPullRequest(pr)}
+		if *policy != "" {
+			opts = append(opts, config.WithPolicy(*policy))
+		}
+
+		cfg, err := config.New(opts...)
+		if err != nil {
+			return err
+		}
+
+		// Labels can only be looked up on GitHub.
+		cfg.Settings.TypeLabels = nil
+
+		checker := plugin.New(cfg.Settings, nil, nil)
+		if err := checker.ReportOffline(stdout, ids...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// pushedBranches returns the names of the local branches being pushed, read
+// from the "<local ref> <local sha> <remote ref> <remote sha>" lines git
+// passes to the pre-push hook. Deleted refs, tags and other refs are left
+// out.
+func pushedBranches(r io.Reader) ([]string, error) {
+	branches := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 {
+			continue
+		}
+		if branch, ok := strings.CutPrefix(fields[0], "refs/heads/"); ok && !slices.Contains(branches, branch) {
+			branches = append(branches, branch)
+		}
+	}
+	return branches, scanner.Err()
+}
+
+// subject returns the first line of a commit message that is neither empty
+// nor a git comment.
+func subject(message string) string {
+	scanner := bufio.NewScanner(strings.NewReader(message))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+	return ""
+}
+
+// generated reports whether git wrote subject itself, for merges and
+// reverts, or whether it belongs to a commit that is squashed into another
+// before merging. Either way the author did not choose the title.
+func generated(subject string) bool {
+	for _, prefix := range []string{"fixup! ", "squash! ", "amend! ", "Merge ", `Revert "`} {
+		if strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// runInstallHooks writes the commit-msg and pre-push hooks of the current
+// git repository. Existing hooks are only replaced with -force.
+func runInstallHooks(args []string, stdout io.Writer) error {
+	flags := newFlagSet("install-hooks", stdout)
+	policy := flags.String("policy", "", "policy file the hooks load")
+	force := flags.Bool("force", false, "replace existing hooks")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dir, err := git("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		file := filepath.Join(dir, hook)
+		if existing, err := os.ReadFile(file); err == nil && !*force && !bytes.Contains(existing, []byte(hookMarker)) {
+			return fmt.Errorf("%s already exists, use -force to replace it", file)
+		}
+		if err := os.WriteFile(file, []byte(hookScript(executable, hook, *policy)), 0o755); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, "🪝", "installed", file)
+	}
+	return nil
+}
+
+// hookScript returns the script of a git hook running the checker.
+func hookScript(executable string, hook string, policy string) string {
+	command := []string{quote(executable), "hook", hook}
+	if policy != "" {
+		command = append(command, "-policy", quote(policy))
+	}
+	if hook == "commit-msg" {
+		command = append(command, `"$1"`)
+	}
+	return fmt.Sprintf("#!/bin/sh\n%s\nexec %s\n", hookMarker, strings.Join(command, " "))
+}
+
+// quote quotes s for the shell.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSubject(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{name: "SubjectFirstLine", message: "feat: add hooks\n\nLonger description.\n", want: "feat: add hooks"},
+		{name: "SubjectAfterComments", message: "# Please enter the commit message\n\n  fix: trim subjects  \n", want: "fix: trim subjects"},
+		{name: "SubjectEmpty", message: "# Please enter the commit message\n#\n", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subject(tt.message); got != tt.want {
+				t.Errorf("subject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunHook(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"policy.yml":  "preset: conventional-commits\nbranchRegexp: ^(feat|fix)/\n",
+		"remote.yml":  "extends: acme/policies:base.yml\n",
+		"COMMIT_OK":   "feat(hooks): check commit messages\n\n# comment\n",
+		"COMMIT_BAD":  "update the hooks\n",
+		"MERGE_MSG":   "Merge branch 'main' of github.com:octocat/hello-world\n",
+		"REVERT_MSG":  "Revert \"feat(hooks): check commit messages\"\n\nThis reverts commit 1111111.\n",
+		"COMMIT_FIX":  "fixup! anything goes\n",
+		"COMMIT_NONE": "# aborted\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	zero := "0000000000000000000000000000000000000000"
+	sha := "1111111111111111111111111111111111111111"
+	pushed := func(refs ...string) string {
+		lines := []string{}
+		for _, ref := range refs {
+			lines = append(lines, strings.Join([]string{ref, sha, ref, zero}, " "))
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		wantErr string
+	}{
+		{name: "RunHookCommitMsg", args: []string{"commit-msg", "-policy", filepath.Join(dir, "policy.yml"), filepath.Join(dir, "COMMIT_OK")}},
+		{
+			name:    "RunHookCommitMsgInvalid",
+			args:    []string{"commit-msg", "-policy", filepath.Join(dir, "policy.yml"), filepath.Join(dir, "COMMIT_BAD")},
+			wantErr: "found 2 errors",
+		},
+		{name: "RunHookCommitMsgAutosquash", args: []string{"commit-msg", "-policy", filepath.Join(dir, "policy.yml"), filepath.Join(dir, "COMMIT_FIX")}},
+		{name: "RunHookCommitMsgMerge", args: []string{"commit-msg", "-policy", filepath.Join(dir, "policy.yml"), filepath.Join(dir, "MERGE_MSG")}},
+		{name: "RunHookCommitMsgRevert", args: []string{"commit-msg", "-policy", filepath.Join(dir, "policy.yml"), filepath.Join(dir, "REVERT_MSG")}},
+		{name: "RunHookCommitMsgEmpty", args: []string{"commit-msg", filepath.Join(dir, "COMMIT_NONE")}},
+		{
+			name:  "RunHookPrePush",
+			args:  []string{"pre-push", "-policy", filepath.Join(dir, "policy.yml")},
+			stdin: pushed("refs/heads/feat/hooks", "refs/tags/v1.0.0") + "(delete) " + zero + " refs/heads/old " + sha + "\n",
+		},
+		{
+			name:    "RunHookPrePushOtherBranch",
+			args:    []string{"pre-push", "-policy", filepath.Join(dir, "policy.yml")},
+			stdin:   pushed("refs/heads/feat/hooks", "refs/heads/wip"),
+			wantErr: "found 1 errors",
+		},
+		{name: "RunHookPrePushNothing", args: []string{"pre-push", "-policy", filepath.Join(dir, "policy.yml")}},
+		{
+			name:    "RunHookRemotePolicy",
+			args:    []string{"commit-msg", "-policy", filepath.Join(dir, "remote.yml"), filepath.Join(dir, "COMMIT_OK")},
+			wantErr: "PLUGIN_POLICY: reading acme/policies:base.yml: remote policies cannot be read offline",
+		},
+		{name: "RunHookUnknown", args: []string{"post-merge"}, wantErr: `unknown git hook "post-merge"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+
+			restore := stdin
+			stdin = strings.NewReader(tt.stdin)
+			t.Cleanup(func() { stdin = restore })
+
+			var out bytes.Buffer
+			err := Run(append([]string{"hook"}, tt.args...), &out)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Fatalf("Run() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunInstallHooks(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+
+	restore := git
+	git = func(args ...string) (string, error) { return dir, nil }
+	t.Cleanup(func() { git = restore })
+
+	var out bytes.Buffer
+	if err := Run([]string{"install-hooks", "-policy", ".github/pr-checker.yml"}, &out); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for hook, command := range map[string]string{
+		"commit-msg": "hook commit-msg -policy '.github/pr-checker.yml' \"$1\"",
+		"pre-push":   "hook pre-push -policy '.github/pr-checker.yml'",
+	} {
+		script, err := os.ReadFile(filepath.Join(dir, hook))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "#!/bin/sh\n" + hookMarker + "\nexec '" + executable + "' " + command + "\n"
+		if string(script) != want {
+			t.Errorf("%s hook = %q, want %q", hook, script, want)
+		}
+	}
+
+	// Hooks installed before are replaced, others are kept.
+	if err := Run([]string{"install-hooks"}, &out); err != nil {
+		t.Fatalf("Run() reinstalling error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "commit-msg"), []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Run([]string{"install-hooks"}, &out); err == nil || !strings.HasSuffix(err.Error(), "already exists, use -force to replace it") {
+		t.Errorf("Run() error = %v, want the existing hook to be kept", err)
+	}
+	if err := Run([]string{"install-hooks", "-force"}, &out); err != nil {
+		t.Errorf("Run() with -force error = %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Package codeowners parses GitHub CODEOWNERS files and resolves the owners
+// of a path.
+package codeowners
+
+import (
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/glob"
+)
+
+// Locations are the paths GitHub looks for a CODEOWNERS file, in order.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Parse reads the rules of a CODEOWNERS file, skipping blank lines and
+// comments.
+func Parse(content string) Ruleset {
+	ruleset := Ruleset{}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 && (i == 0 || line[i-1] != '\\') {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		ruleset = append(ruleset, Rule{
+			Pattern: strings.ReplaceAll(fields[0], `\#`, "#"),
+			Owners:  fields[1:],
+		})
+	}
+
+	return ruleset
+}
+
+// Owners returns the owners of path according to the last matching rule. A
+// matching rule without owners leaves the path unowned.
+func (r Ruleset) Owners(path string) []string {
+	for i := len(r) - 1; i >= 0; i-- {
+		if r[i].Matches(path) {
+			return r[i].Owners
+		}
+	}
+	return nil
+}
+
+// Matches reports whether path matches the rule pattern using the gitignore
+// style semantics of CODEOWNERS: patterns without a slash match at any
+// depth, a leading or inner slash anchors the pattern to the repository root
+// and a pattern matching a directory also matches everything below it,
+// except when its last segment is a wildcard such as "docs/*".
+func (r Rule) Matches(path string) bool {
+	pattern := r.Pattern
+	if pattern == "*" {
+		return true
+	}
+
+	directory := strings.HasSuffix(pattern, "/")
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+	if !anchored && !strings.HasPrefix(pattern, "**") {
+		pattern = "**/" + pattern
+	}
+
+	if !directory && glob.Match(pattern, path) {
+		return true
+	}
+
+	last := pattern[strings.LastIndex(pattern, "/")+1:]
+	if strings.Contains(last, "*") && last != "**" {
+		return false
+	}
+	return glob.Match(pattern+"/**", path) && !glob.Match(pattern, path)
+}
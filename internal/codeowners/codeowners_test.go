@@ -0,0 +1,42 @@
+package codeowners
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRuleset_Owners(t *testing.T) {
+	ruleset := Parse(`# Default owners
+*       @org/everyone
+
+*.js    @js-owner # inline comment
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+**/logs @org/logs
+/scripts/ @doctocat @octocat
+/unowned
+`)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "main.go", want: []string{"@org/everyone"}},
+		{path: "web/app.js", want: []string{"@js-owner"}},
+		{path: "build/logs/out.txt", want: []string{"@org/logs"}},
+		{path: "apps", want: []string{"@org/everyone"}},
+		{path: "src/build/logs/out.txt", want: []string{"@org/logs"}},
+		{path: "docs/getting-started.md", want: []string{"docs@example.com"}},
+		{path: "docs/build-app/troubleshooting.md", want: []string{"@org/everyone"}},
+		{path: "apps/web/index.html", want: []string{"@octocat"}},
+		{path: "src/apps/web/index.html", want: []string{"@octocat"}},
+		{path: "scripts/deploy.sh", want: []string{"@doctocat", "@octocat"}},
+		{path: "unowned/file.txt", want: []string{}},
+	}
+	for _, tt := range tests {
+		if got := ruleset.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Ruleset.Owners(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
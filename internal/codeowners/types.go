@@ -0,0 +1,12 @@
+package codeowners
+
+// Ruleset is a parsed CODEOWNERS file. Rules are kept in file order, the
+// last matching rule wins.
+type Ruleset []Rule
+
+// Rule assigns owners, "@user", "@org/team" or an email address, to the
+// paths matching Pattern.
+type Rule struct {
+	Pattern string
+	Owners  []string
+}
@@ -1,43 +1,185 @@
 package config
 
 import (
-	"github.com/go-playground/validator/v10"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 var (
+	policy            = "plugin_policy"
+	preset            = "plugin_preset"
+	profiles          = "plugin_profiles"
+	dryRun            = "plugin_dry_run"
+	changelog         = "plugin_changelog"
 	prefixes          = "plugin_prefixes"
-	regexp            = "plugin_regexp"
+	types             = "plugin_types"
+	typeLabels        = "plugin_type_labels"
+	titleRegexp       = "plugin_regexp"
 	skipOnLabels      = "plugin_skip_on_labels"
 	ignoreGitHubError = "plugin_ignore_github_error"
 	checklist         = "plugin_checklist"
 	checklistTitle    = "plugin_checklist_title"
+	checklistTitleRe  = "plugin_checklist_title_regexp"
+	checklistOptional = "plugin_checklist_optional"
+	checklistTemplate = "plugin_checklist_template"
+	checklists        = "plugin_checklists"
+	requiredLabels    = "plugin_required_labels"
+	rules             = "plugin_rules"
+	codeowners        = "plugin_codeowners"
+	codeownersFile    = "plugin_codeowners_file"
+	minApprovals      = "plugin_min_approvals"
+	dismissStale      = "plugin_dismiss_stale_approvals"
+	approverTeam      = "plugin_approver_team"
+	approverTeamMode  = "plugin_approver_team_mode"
+	draft             = "plugin_draft"
+	mergeable         = "plugin_mergeable"
+	maxBehind         = "plugin_max_behind"
+	mergeableRetries  = "plugin_mergeable_retries"
+	mergeableInterval = "plugin_mergeable_interval"
+	freezeBranches    = "plugin_freeze_branches"
+	freezeWindows     = "plugin_freeze_windows"
+	freezeTimezone    = "plugin_freeze_timezone"
+	freezeOverride    = "plugin_freeze_override_label"
+	freezeSeverity    = "plugin_freeze_severity"
+	signedCommits     = "plugin_signed_commits"
+	commitHygiene     = "plugin_commit_hygiene"
+	maxCommits        = "plugin_max_commits"
+	branchRegexp      = "plugin_branch_regexp"
+	branchTargets     = "plugin_branch_targets"
+	issueReference    = "plugin_issue_reference"
+	issueKeys         = "plugin_issue_keys"
+	issueVerify       = "plugin_issue_verify"
+	issueTrackerURL   = "plugin_issue_tracker_url"
+	requiredSections  = "plugin_required_sections"
+	sectionMinLength  = "plugin_section_min_length"
+	template          = "plugin_template"
 	title             = "drone_pull_request_title"
 	githubToken       = "github_token"
+	trackerToken      = "issue_tracker_token"
 	repo              = "drone_repo_name"
 	owner             = "drone_repo_owner"
 	pullRequest       = "drone_pull_request"
+	sourceBranch      = "drone_source_branch"
+	targetBranch      = "drone_target_branch"
 )
 
 var envVars = []string{
+	policy,
+	preset,
+	profiles,
+	dryRun,
+	changelog,
 	prefixes,
-	regexp,
+	types,
+	typeLabels,
+	titleRegexp,
 	skipOnLabels,
 	ignoreGitHubError,
 	checklist,
 	checklistTitle,
+	checklistTitleRe,
+	checklistOptional,
+	checklistTemplate,
+	checklists,
+	requiredLabels,
+	rules,
+	codeowners,
+	codeownersFile,
+	minApprovals,
+	dismissStale,
+	approverTeam,
+	approverTeamMode,
+	draft,
+	mergeable,
+	maxBehind,
+	mergeableRetries,
+	mergeableInterval,
+	freezeBranches,
+	freezeWindows,
+	freezeTimezone,
+	freezeOverride,
+	freezeSeverity,
+	signedCommits,
+	commitHygiene,
+	maxCommits,
+	branchRegexp,
+	branchTargets,
+	issueReference,
+	issueKeys,
+	issueVerify,
+	issueTrackerURL,
+	requiredSections,
+	sectionMinLength,
+	template,
 	title,
 	githubToken,
+	trackerToken,
 	repo,
 	owner,
 	pullRequest,
+	sourceBranch,
+	targetBranch,
+}
+
+// listSettings are the settings holding a list of values.
+var listSettings = []string{
+	prefixes,
+	changelog,
+	types,
+	typeLabels,
+	skipOnLabels,
+	checklistOptional,
+	requiredLabels,
+	freezeBranches,
+	freezeWindows,
+	branchTargets,
+	issueKeys,
+	requiredSections,
 }
 
-func New() (*Config, error) {
+// defaults are the values of settings that are not given.
+var defaults = map[string]interface{}{
+	checklistTitle:    "## Checklist",
+	ignoreGitHubError: true,
+	dryRun:            false,
+	checklist:         false,
+	checklistTitleRe:  false,
+	checklistTemplate: false,
+	codeowners:        false,
+	minApprovals:      0,
+	dismissStale:      false,
+	approverTeamMode:  ApproverTeamDouble,
+	draft:             DraftRun,
+	mergeable:         false,
+	maxBehind:         -1,
+	mergeableRetries:  10,
+	mergeableInterval: "3s",
+	freezeTimezone:    "UTC",
+	freezeSeverity:    SeverityError,
+	signedCommits:     false,
+	commitHygiene:     false,
+	maxCommits:        0,
+	issueReference:    false,
+	issueVerify:       false,
+	sectionMinLength:  10,
+	template:          ".github/pull_request_template.md",
+}
+
+func New(opts ...Option) (*Config, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	v := viper.New()
-	v.SetDefault(checklistTitle, "## Checklist")
-	v.SetDefault(ignoreGitHubError, true)
-	v.SetDefault(checklist, false)
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
 
 	for _, envVar := range envVars {
 		if err := v.BindEnv(envVar); err != nil {
@@ -45,29 +187,251 @@ func New() (*Config, error) {
 		}
 	}
 
+	// Woodpecker passes the pull request details in its own variables.
+	ci := woodpeckerEnv()
+	for key, value := range ci {
+		v.Set(key, value)
+	}
+	o.apply(v)
+
+	// The policy file sits between the defaults and the environment, so
+	// settings given to the step override it.
+	effective := map[string]interface{}{}
+	if file := v.GetString(policy); file != "" {
+		loaded, err := loadPolicy(file, v.GetString(githubToken), o.offline)
+		if err != nil {
+			return nil, err
+		}
+		keys := policySettings()
+		settings := map[string]interface{}{}
+		for name, value := range loaded {
+			// Profiles are keyed by branch globs, which viper would mangle.
+			if keys[name] == profiles {
+				continue
+			}
+			settings[keys[name]] = value
+		}
+		if err := v.MergeConfigMap(settings); err != nil {
+			return nil, err
+		}
+		effective = loaded
+	}
+
+	// Profiles for the target branch override every other source.
+	branchProfiles, err := parseProfiles(v, effective[settingName(profiles)])
+	if err != nil {
+		return nil, err
+	}
+	applied := applyProfiles(v, branchProfiles)
+
+	applyPreset(v)
+
+	lists := map[string][]string{}
+	for _, key := range listSettings {
+		lists[key] = getList(v, key)
+	}
+
+	labelsByType, err := parseTypeLabels(lists[typeLabels])
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := parseChecklists(getJSON(v, checklists))
+	if err != nil {
+		return nil, err
+	}
+
+	pathRules, err := parseRules(getJSON(v, rules))
+	if err != nil {
+		return nil, err
+	}
+
+	windows, err := parseFreezeWindows(lists[freezeWindows], v.GetString(freezeTimezone))
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{
 		Settings: Settings{
-			Prefixes:          v.GetString(prefixes),
-			Regexp:            v.GetString(regexp),
-			SkipOnLabels:      v.GetString(skipOnLabels),
+			Preset:            v.GetString(preset),
+			DryRun:            v.GetBool(dryRun),
+			Prefixes:          lists[prefixes],
+			Changelog:         lists[changelog],
+			Types:             lists[types],
+			TypeLabels:        labelsByType,
+			Regexp:            v.GetString(titleRegexp),
+			SkipOnLabels:      lists[skipOnLabels],
 			IgnoreGitHubError: v.GetBool(ignoreGitHubError),
 			Title:             v.GetString(title),
 			ChecklistTitle:    v.GetString(checklistTitle),
+			ChecklistTitleRe:  v.GetBool(checklistTitleRe),
+			ChecklistOptional: lists[checklistOptional],
+			ChecklistTemplate: v.GetBool(checklistTemplate),
+			Checklists:        sections,
+			RequiredLabels:    lists[requiredLabels],
+			Rules:             pathRules,
+			Codeowners:        v.GetBool(codeowners),
+			CodeownersFile:    v.GetString(codeownersFile),
+			MinApprovals:      v.GetInt(minApprovals),
+			DismissStale:      v.GetBool(dismissStale),
+			ApproverTeam:      v.GetString(approverTeam),
+			ApproverTeamMode:  v.GetString(approverTeamMode),
+			Draft:             v.GetString(draft),
+			Mergeable:         v.GetBool(mergeable),
+			MaxBehind:         v.GetInt(maxBehind),
+			MergeableRetries:  v.GetInt(mergeableRetries),
+			MergeableInterval: v.GetDuration(mergeableInterval),
+			FreezeBranches:    lists[freezeBranches],
+			FreezeWindows:     windows,
+			FreezeOverride:    v.GetString(freezeOverride),
+			FreezeSeverity:    v.GetString(freezeSeverity),
 			Repo:              v.GetString(repo),
 			Owner:             v.GetString(owner),
 			PullRequest:       v.GetInt(pullRequest),
 			Checklist:         v.GetBool(checklist),
+			SignedCommits:     v.GetBool(signedCommits),
+			CommitHygiene:     v.GetBool(commitHygiene),
+			MaxCommits:        v.GetInt(maxCommits),
+			BranchRegexp:      v.GetString(branchRegexp),
+			BranchTargets:     lists[branchTargets],
+			SourceBranch:      v.GetString(sourceBranch),
+			TargetBranch:      v.GetString(targetBranch),
+			IssueReference:    v.GetBool(issueReference),
+			IssueKeys:         lists[issueKeys],
+			IssueVerify:       v.GetBool(issueVerify),
+			RequiredSections:  lists[requiredSections],
+			SectionMinLength:  v.GetInt(sectionMinLength),
+			Template:          v.GetString(template),
+		},
+		Github:   GitHub{Token: v.GetString(githubToken)},
+		Policy:   effective,
+		Profiles: applied,
+		Values: resolve(v, lists, origins{
+			preset:   presetKeys(v),
+			policy:   effective,
+			profiles: profileKeys(branchProfiles, applied),
+			flags:    o.overrides,
+			ci:       ci,
+		}),
+		Tracker: Tracker{
+			URL:   v.GetString(issueTrackerURL),
+			Token: v.GetString(trackerToken),
 		},
-		Github: GitHub{Token: v.GetString(githubToken)},
 	}
 
-	return cfg.validate()
+	return cfg.validate(o.settingsOnly)
 }
 
-func (config *Config) validate() (*Config, error) {
-	validate := validator.New(validator.WithRequiredStructEnabled())
-	if err := validate.Struct(config); err != nil {
-		return nil, err
+// getJSON returns the structured setting key as JSON. Drone passes these as
+// JSON strings while policy files hold them as YAML.
+func getJSON(v *viper.Viper, key string) string {
+	switch value := v.Get(key).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(encoded)
+	}
+}
+
+// parseChecklists decodes the checklists setting, which Drone passes as JSON.
+func parseChecklists(raw string) ([]Checklist, error) {
+	sections := []Checklist{}
+	if raw == "" {
+		return sections, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &sections); err != nil {
+		return nil, fmt.Errorf("%s must be a JSON list of checklists: %w", env(checklists), err)
 	}
-	return config, nil
+
+	for _, section := range sections {
+		if section.Title == "" {
+			return nil, fmt.Errorf("%s: every checklist needs a title", env(checklists))
+		}
+		switch section.Severity {
+		case "", SeverityError, SeverityWarning:
+		default:
+			return nil, fmt.Errorf("%s: unknown severity %q for %q", env(checklists), section.Severity, section.Title)
+		}
+	}
+
+	return sections, nil
+}
+
+// SkippableChecks are the step ids of the checks path rules can skip.
+var SkippableChecks = []string{
+	"prefix",
+	"regexp",
+	"type",
+	"branch",
+	"target",
+	"issue",
+	"required-labels",
+	"sections",
+	"checklist",
+	"changelog",
+	"freeze",
+	"mergeable",
+	"reviews",
+	"codeowners",
+	"signatures",
+	"commits",
+}
+
+// parseRules decodes the path scoped rules setting, which Drone passes as
+// JSON.
+func parseRules(raw string) ([]Rule, error) {
+	pathRules := []Rule{}
+	if raw == "" {
+		return pathRules, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &pathRules); err != nil {
+		return nil, fmt.Errorf("%s must be a JSON list of rules: %w", env(rules), err)
+	}
+
+	for i, rule := range pathRules {
+		if len(rule.Paths) == 0 {
+			return nil, fmt.Errorf("%s: rule %d has no paths", env(rules), i+1)
+		}
+		for _, id := range rule.Skip {
+			if !slices.Contains(SkippableChecks, id) {
+				return nil, fmt.Errorf(
+					"%s: rule %d skips unknown check %q, want one of %s",
+					env(rules), i+1, id, strings.Join(SkippableChecks, ", "),
+				)
+			}
+		}
+	}
+
+	return pathRules, nil
+}
+
+// parseFreezeWindows parses the freeze windows in the configured timezone.
+func parseFreezeWindows(specs []string, timezone string) ([]FreezeWindow, error) {
+	windows := []FreezeWindow{}
+	if len(specs) == 0 {
+		return windows, nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", env(freezeTimezone), err)
+	}
+
+	for _, spec := range specs {
+		window, err := ParseFreezeWindow(spec, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", env(freezeWindows), err)
+		}
+		windows = append(windows, window)
+	}
+
+	return windows, nil
 }
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr []string
+	}{
+		{
+			name: "NewChecklistDisabledWithoutOtherSettings",
+			env: map[string]string{
+				"PLUGIN_CHECKLIST":         "false",
+				"PLUGIN_PREFIXES":          "feat:",
+				"DRONE_PULL_REQUEST_TITLE": "feat: add a new feature",
+			},
+		},
+		{
+			name: "NewBracketedListSetting",
+			env: map[string]string{
+				"PLUGIN_PREFIXES":          "[feat],[fix]",
+				"DRONE_PULL_REQUEST_TITLE": "[feat] add a new feature",
+			},
+		},
+		{
+			name: "NewRuleSkipsUnknownCheck",
+			env: map[string]string{
+				"PLUGIN_RULES": `[{"paths": ["docs/**"], "skip": ["commits"]}, {"paths": ["*.md"], "skip": ["commit"]}]`,
+			},
+			wantErr: []string{`PLUGIN_RULES: rule 2 skips unknown check "commit", want one of prefix, regexp, type, branch, target, issue, required-labels, sections, checklist, changelog, freeze, mergeable, reviews, codeowners, signatures, commits`},
+		},
+		{
+			name: "NewNothingEnabled",
+			env:  map[string]string{},
+		},
+		{
+			name: "NewMissingAPISettings",
+			env: map[string]string{
+				"PLUGIN_CHECKLIST": "true",
+				"PLUGIN_REGEXP":    "^feat:",
+			},
+			wantErr: []string{
+				"DRONE_PULL_REQUEST_TITLE is required when the regexp check is enabled",
+				"GITHUB_TOKEN is required when the checklist check is enabled",
+				"DRONE_REPO_OWNER is required when the checklist check is enabled",
+				"DRONE_REPO_NAME is required when the checklist check is enabled",
+				"DRONE_PULL_REQUEST is required when the checklist check is enabled",
+			},
+		},
+		{
+			name: "NewInvalidEnumerations",
+			env: map[string]string{
+				"PLUGIN_DRAFT":              "ignore",
+				"PLUGIN_PRESET":             "commitizen",
+				"PLUGIN_APPROVER_TEAM":      "leads",
+				"PLUGIN_APPROVER_TEAM_MODE": "triple",
+				"GITHUB_TOKEN":              "token",
+				"DRONE_REPO_OWNER":          "octocat",
+				"DRONE_REPO_NAME":           "hello-world",
+				"DRONE_PULL_REQUEST":        "1",
+			},
+			wantErr: []string{
+				`PLUGIN_DRAFT must be one of run, skip, fail, warn, got "ignore"`,
+				`PLUGIN_APPROVER_TEAM_MODE must be one of double, mandatory, got "triple"`,
+				`PLUGIN_PRESET must be one of angular, conventional-commits, gitmoji, semantic-release, got "commitizen"`,
+				`PLUGIN_APPROVER_TEAM must be given as org/team, got "leads"`,
+			},
+		},
+		{
+			name: "NewInvalidPatterns",
+			env: map[string]string{
+				"PLUGIN_REGEXP":                 "^feat(",
+				"PLUGIN_BRANCH_REGEXP":          "^feature/[a-z",
+				"PLUGIN_BRANCH_TARGETS":         "main=release/*,develop",
+				"PLUGIN_CHECKLIST":              "true",
+				"PLUGIN_CHECKLIST_TITLE":        "Checklist (",
+				"PLUGIN_CHECKLIST_TITLE_REGEXP": "true",
+				"DRONE_PULL_REQUEST_TITLE":      "feat: add a new feature",
+				"DRONE_SOURCE_BRANCH":           "feature/a",
+				"DRONE_TARGET_BRANCH":           "main",
+				"GITHUB_TOKEN":                  "token",
+				"DRONE_REPO_OWNER":              "octocat",
+				"DRONE_REPO_NAME":               "hello-world",
+				"DRONE_PULL_REQUEST":            "1",
+			},
+			wantErr: []string{
+				"PLUGIN_REGEXP is not a valid regular expression: error parsing regexp: missing closing ): `^feat(`",
+				"PLUGIN_BRANCH_REGEXP is not a valid regular expression: error parsing regexp: missing closing ]: `[a-z`",
+				"PLUGIN_CHECKLIST_TITLE is not a valid regular expression: error parsing regexp: missing closing ): `Checklist (`",
+				`PLUGIN_BRANCH_TARGETS: rule "develop" must be given as target=source`,
+			},
+		},
+		{
+			name: "NewLiteralChecklistTitle",
+			env: map[string]string{
+				"PLUGIN_CHECKLIST":       "true",
+				"PLUGIN_CHECKLIST_TITLE": "Checklist (",
+				"GITHUB_TOKEN":           "token",
+				"DRONE_REPO_OWNER":       "octocat",
+				"DRONE_REPO_NAME":        "hello-world",
+				"DRONE_PULL_REQUEST":     "1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range envVars {
+				t.Setenv(strings.ToUpper(key), "")
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := New()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("New() error = %v, want none", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("New() succeeded, want errors %v", tt.wantErr)
+			}
+			if got := strings.Split(err.Error(), "\n"); strings.Join(got, "\n") != strings.Join(tt.wantErr, "\n") {
+				t.Errorf("New() error =\n%v\nwant\n%v", err, strings.Join(tt.wantErr, "\n"))
+			}
+		})
+	}
+}
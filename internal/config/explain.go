@@ -0,0 +1,189 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Sources of setting values, from the lowest precedence to the highest.
+const (
+	SourceUnset   = "unset"
+	SourceDefault = "default"
+	SourcePreset  = "preset"
+	SourcePolicy  = "policy"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+	SourceProfile = "profile"
+)
+
+// redacted replaces the values of secrets when explaining the configuration.
+const redacted = "[redacted]"
+
+// secrets are never printed.
+var secrets = []string{githubToken, trackerToken}
+
+// Value is a resolved setting together with where its value comes from.
+type Value struct {
+	// Name is the Drone setting name, or the environment variable for
+	// settings that cannot be given to the step.
+	Name   string
+	Env    string
+	Value  string
+	Source string
+}
+
+// CheckState tells whether a check is enabled and by which settings.
+type CheckState struct {
+	Check    string
+	Enabled  bool
+	Settings []Value
+}
+
+// origins records which settings the preset, policy, profiles, command
+// line flags and CI specific variables provide.
+type origins struct {
+	preset   map[string]string
+	policy   map[string]interface{}
+	profiles map[string]string
+	flags    map[string]interface{}
+	ci       map[string]string
+}
+
+// source returns where the value of key comes from.
+func (o origins) source(key string) string {
+	if pattern, ok := o.profiles[key]; ok {
+		return fmt.Sprintf("%s %s", SourceProfile, pattern)
+	}
+	if _, ok := o.flags[key]; ok {
+		return SourceFlag
+	}
+	if _, ok := o.ci[key]; ok || os.Getenv(env(key)) != "" {
+		return SourceEnv
+	}
+	if _, ok := o.policy[settingName(key)]; ok {
+		return SourcePolicy
+	}
+	if _, ok := o.preset[key]; ok {
+		return SourcePreset
+	}
+	if _, ok := defaults[key]; ok {
+		return SourceDefault
+	}
+	return SourceUnset
+}
+
+// resolve returns every setting with its final value and source.
+func resolve(v *viper.Viper, lists map[string][]string, o origins) []Value {
+	values := []Value{}
+	for _, key := range envVars {
+		name := env(key)
+		if strings.HasPrefix(key, "plugin_") {
+			name = settingName(key)
+		}
+
+		value := v.GetString(key)
+		if list, ok := lists[key]; ok {
+			value = encode(list)
+		} else if key == checklists || key == rules || key == profiles {
+			value = getJSON(v, key)
+		}
+
+		source := o.source(key)
+		if value != "" && slices.Contains(secrets, key) {
+			value = redacted
+		}
+
+		values = append(values, Value{Name: name, Env: env(key), Value: value, Source: source})
+	}
+	return values
+}
+
+// Checks returns the checks that can be enabled and the settings deciding
+// whether they are.
+func (config *Config) Checks() []CheckState {
+	byEnv := map[string]Value{}
+	for _, value := range config.Values {
+		byEnv[value.Env] = value
+	}
+
+	checks := []CheckState{}
+	for _, requirement := range requirements {
+		state := CheckState{Check: requirement.check, Enabled: requirement.enabled(config.Settings)}
+		for _, key := range requirement.by {
+			state.Settings = append(state.Settings, byEnv[env(key)])
+		}
+		checks = append(checks, state)
+	}
+	return checks
+}
+
+// Explain writes the resolved settings with their sources, the checks they
+// enable and the problems found validating them to w.
+func (config *Config) Explain(w io.Writer) {
+	for _, profile := range config.Profiles {
+		fmt.Fprintln(w, "🧭", slog.String("profile", profile))
+	}
+
+	for _, value := range config.Values {
+		fmt.Fprintln(
+			w,
+			"⚙️",
+			slog.String("setting", value.Name),
+			slog.String("env", value.Env),
+			slog.String("source", value.Source),
+			slog.String("value", value.Value),
+		)
+	}
+
+	for _, check := range config.Checks() {
+		reasons := []string{}
+		for _, value := range check.Settings {
+			reasons = append(reasons, fmt.Sprintf("%s=%s (%s)", value.Name, value.Value, value.Source))
+		}
+		fmt.Fprintln(
+			w,
+			"🔎",
+			slog.String("check", check.Check),
+			slog.Bool("enabled", check.Enabled),
+			slog.String("because", strings.Join(reasons, ", ")),
+		)
+	}
+
+	for _, problem := range config.Problems {
+		fmt.Fprintln(w, "❌", slog.String("config", problem.Error()))
+	}
+}
+
+// presetKeys returns the settings the selected preset provides.
+func presetKeys(v *viper.Viper) map[string]string {
+	return presets[v.GetString(preset)]
+}
+
+// profileKeys returns the settings set by the applied profiles, with the
+// pattern of the profile that set them last.
+func profileKeys(branchProfiles map[string]map[string]interface{}, applied []string) map[string]string {
+	keys := policySettings()
+	set := map[string]string{}
+	for _, pattern := range applied {
+		for name := range branchProfiles[pattern] {
+			set[keys[name]] = pattern
+		}
+	}
+	return set
+}
+
+// encode formats a structured value for printing.
+func encode(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
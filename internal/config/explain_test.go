@@ -0,0 +1,106 @@
+package config
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfig_Explain(t *testing.T) {
+	dir := writePolicies(t, map[string]string{
+		"policy.yml": `
+preset: angular
+minApprovals: 1
+skipOnLabels: [skip-checks]
+profiles:
+  release/*:
+    minApprovals: 2
+`,
+	})
+	setPolicyEnv(t, map[string]string{
+		"PLUGIN_POLICY":            filepath.Join(dir, "policy.yml"),
+		"PLUGIN_SKIP_ON_LABELS":    "no-checks, wip",
+		"PLUGIN_DRY_RUN":           "true",
+		"DRONE_PULL_REQUEST_TITLE": "fix: explain settings",
+		"DRONE_TARGET_BRANCH":      "release/1.0",
+		"GITHUB_TOKEN":             "ghp_secret",
+		"DRONE_REPO_OWNER":         "octocat",
+		"DRONE_REPO_NAME":          "hello-world",
+		"DRONE_PULL_REQUEST":       "1",
+	})
+
+	cfg, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !cfg.Settings.DryRun {
+		t.Errorf("DryRun = false, want true")
+	}
+
+	want := map[string]Value{
+		"PLUGIN_MIN_APPROVALS":     {Name: "minApprovals", Env: "PLUGIN_MIN_APPROVALS", Value: "2", Source: "profile release/*"},
+		"PLUGIN_SKIP_ON_LABELS":    {Name: "skipOnLabels", Env: "PLUGIN_SKIP_ON_LABELS", Value: `["no-checks","wip"]`, Source: SourceEnv},
+		"PLUGIN_PRESET":            {Name: "preset", Env: "PLUGIN_PRESET", Value: "angular", Source: SourcePolicy},
+		"PLUGIN_REGEXP":            {Name: "regexp", Env: "PLUGIN_REGEXP", Value: conventionalTitle, Source: SourcePreset},
+		"PLUGIN_CHECKLIST_TITLE":   {Name: "checklistTitle", Env: "PLUGIN_CHECKLIST_TITLE", Value: "## Checklist", Source: SourceDefault},
+		"PLUGIN_BRANCH_REGEXP":     {Name: "branchRegexp", Env: "PLUGIN_BRANCH_REGEXP", Value: "", Source: SourceUnset},
+		"GITHUB_TOKEN":             {Name: "GITHUB_TOKEN", Env: "GITHUB_TOKEN", Value: redacted, Source: SourceEnv},
+		"DRONE_PULL_REQUEST_TITLE": {Name: "DRONE_PULL_REQUEST_TITLE", Env: "DRONE_PULL_REQUEST_TITLE", Value: "fix: explain settings", Source: SourceEnv},
+	}
+	for _, value := range cfg.Values {
+		if expected, ok := want[value.Env]; ok && value != expected {
+			t.Errorf("Values[%s] = %+v, want %+v", value.Env, value, expected)
+		}
+	}
+
+	var out bytes.Buffer
+	cfg.Explain(&out)
+	explained := out.String()
+
+	if strings.Contains(explained, "ghp_secret") {
+		t.Errorf("Explain() printed the GitHub token:\n%s", explained)
+	}
+	for _, line := range []string{
+		"🧭 profile=release/*",
+		"🔎 check=reviews enabled=true because=minApprovals=2 (profile release/*)",
+		"🔎 check=branch enabled=false because=branchRegexp= (unset)",
+	} {
+		if !strings.Contains(explained, line+"\n") {
+			t.Errorf("Explain() does not contain %q:\n%s", line, explained)
+		}
+	}
+}
+
+func TestConfig_ExplainProblems(t *testing.T) {
+	setPolicyEnv(t, map[string]string{
+		"PLUGIN_SKIP_ON_LABELS": "wip",
+		"PLUGIN_DRAFT":          "ignore",
+		"PLUGIN_DRY_RUN":        "true",
+	})
+
+	cfg, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v, want the problems kept in a dry run", err)
+	}
+
+	var out bytes.Buffer
+	cfg.Explain(&out)
+	explained := out.String()
+
+	for _, line := range []string{
+		"🔎 check=labels enabled=true because=skipOnLabels=[\"wip\"] (env)",
+		"❌ config=GITHUB_TOKEN is required when the labels check is enabled",
+		"❌ config=DRONE_PULL_REQUEST is required when the labels check is enabled",
+		"❌ config=PLUGIN_DRAFT must be one of run, skip, fail, warn, got \"ignore\"",
+	} {
+		if !strings.Contains(explained, line+"\n") {
+			t.Errorf("Explain() does not contain %q:\n%s", line, explained)
+		}
+	}
+
+	t.Setenv("PLUGIN_DRY_RUN", "false")
+	if _, err := New(); err == nil {
+		t.Errorf("New() succeeded without a dry run, want the problems reported")
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	weekdays = map[string]time.Weekday{
+		"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+		"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+	}
+	recurringRe = regexp.MustCompile(`(?i)^([a-z]{3})(?:-([a-z]{3}))?(?:\s+(\d{1,2}:\d{2})-(\d{1,2}:\d{2}))?$`)
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02T15:04"
+)
+
+// FreezeWindow is a period during which merges into protected branches are
+// frozen. It is either recurring, on a range of weekdays optionally limited
+// to a time of day, or a one-off range of dates.
+type FreezeWindow struct {
+	Spec string
+
+	// Recurring windows.
+	days       [7]bool
+	start, end int // minutes since midnight, end exclusive
+
+	// One-off windows, end exclusive.
+	from, to time.Time
+
+	location *time.Location
+}
+
+// ParseFreezeWindow parses a freeze window specification in loc:
+//
+//	Fri 15:00-24:00             every Friday from 15:00
+//	Sat-Sun                     every weekend
+//	2026-12-20/2027-01-02       from the 20th up to and including the 2nd
+//	2026-12-20T18:00/2026-12-21T09:00
+func ParseFreezeWindow(spec string, loc *time.Location) (FreezeWindow, error) {
+	spec = strings.TrimSpace(spec)
+	window := FreezeWindow{Spec: spec, location: loc}
+
+	if from, to, found := strings.Cut(spec, "/"); found {
+		var err error
+		if window.from, err = parseFreezeTime(from, loc, false); err != nil {
+			return window, fmt.Errorf("invalid freeze window %q: %w", spec, err)
+		}
+		if window.to, err = parseFreezeTime(to, loc, true); err != nil {
+			return window, fmt.Errorf("invalid freeze window %q: %w", spec, err)
+		}
+		if !window.to.After(window.from) {
+			return window, fmt.Errorf("invalid freeze window %q: end is before start", spec)
+		}
+		return window, nil
+	}
+
+	match := recurringRe.FindStringSubmatch(spec)
+	if match == nil {
+		return window, fmt.Errorf("invalid freeze window %q", spec)
+	}
+
+	first, ok := weekdays[strings.ToLower(match[1])]
+	if !ok {
+		return window, fmt.Errorf("invalid freeze window %q: unknown day %q", spec, match[1])
+	}
+	last := first
+	if match[2] != "" {
+		if last, ok = weekdays[strings.ToLower(match[2])]; !ok {
+			return window, fmt.Errorf("invalid freeze window %q: unknown day %q", spec, match[2])
+		}
+	}
+	for day := first; ; day = (day + 1) % 7 {
+		window.days[day] = true
+		if day == last {
+			break
+		}
+	}
+
+	window.start, window.end = 0, 24*60
+	if match[3] != "" {
+		var err error
+		if window.start, err = parseMinutes(match[3]); err != nil {
+			return window, fmt.Errorf("invalid freeze window %q: %w", spec, err)
+		}
+		if window.end, err = parseMinutes(match[4]); err != nil {
+			return window, fmt.Errorf("invalid freeze window %q: %w", spec, err)
+		}
+		if window.end <= window.start {
+			return window, fmt.Errorf("invalid freeze window %q: end is before start", spec)
+		}
+	}
+
+	return window, nil
+}
+
+// Contains reports whether t falls into the window.
+func (w FreezeWindow) Contains(t time.Time) bool {
+	t = t.In(w.location)
+	if !w.from.IsZero() {
+		return !t.Before(w.from) && t.Before(w.to)
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	return w.days[t.Weekday()] && minutes >= w.start && minutes < w.end
+}
+
+// parseFreezeTime parses a date or date and time. A date on its own as the
+// end of a range includes that whole day.
+func parseFreezeTime(value string, loc *time.Location, end bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.ParseInLocation(dateTimeLayout, value, loc); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(dateLayout, value, loc)
+	if err != nil {
+		return t, fmt.Errorf("%q is neither %s nor %s", value, dateLayout, dateTimeLayout)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+func parseMinutes(value string) (int, error) {
+	hours, minutes, _ := strings.Cut(value, ":")
+	h, err := strconv.Atoi(hours)
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil {
+		return 0, err
+	}
+	if h > 24 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	return h*60 + m, nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFreezeWindow_Contains(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := func(value string) time.Time {
+		t, _ := time.ParseInLocation("2006-01-02 15:04", value, berlin)
+		return t
+	}
+
+	// 2026-10-16 is a Friday.
+	tests := []struct {
+		spec string
+		time time.Time
+		want bool
+	}{
+		{spec: "Fri 15:00-24:00", time: at("2026-10-16 15:00"), want: true},
+		{spec: "Fri 15:00-24:00", time: at("2026-10-16 14:59"), want: false},
+		{spec: "Fri 15:00-24:00", time: at("2026-10-16 14:30").UTC(), want: false},
+		{spec: "Fri 15:00-24:00", time: at("2026-10-16 15:30").UTC(), want: true},
+		{spec: "sat-sun", time: at("2026-10-18 23:59"), want: true},
+		{spec: "Sat-Sun", time: at("2026-10-19 00:00"), want: false},
+		{spec: "Fri-Mon", time: at("2026-10-19 12:00"), want: true},
+		{spec: "2026-12-20/2027-01-02", time: at("2027-01-02 23:59"), want: true},
+		{spec: "2026-12-20/2027-01-02", time: at("2027-01-03 00:00"), want: false},
+		{spec: "2026-12-20T18:00/2026-12-21T09:00", time: at("2026-12-21 08:59"), want: true},
+		{spec: "2026-12-20T18:00/2026-12-21T09:00", time: at("2026-12-20 17:59"), want: false},
+	}
+	for _, tt := range tests {
+		window, err := ParseFreezeWindow(tt.spec, berlin)
+		if err != nil {
+			t.Fatalf("ParseFreezeWindow(%q) error = %v", tt.spec, err)
+		}
+		if got := window.Contains(tt.time); got != tt.want {
+			t.Errorf("FreezeWindow(%q).Contains(%v) = %v, want %v", tt.spec, tt.time, got, tt.want)
+		}
+	}
+}
+
+func TestParseFreezeWindow_Invalid(t *testing.T) {
+	for _, spec := range []string{"Friday", "Fri 16:00-15:00", "Fri 25:00-26:00", "2027-01-02/2026-12-20", "tomorrow/later"} {
+		if _, err := ParseFreezeWindow(spec, time.UTC); err == nil {
+			t.Errorf("ParseFreezeWindow(%q) succeeded, want an error", spec)
+		}
+	}
+}
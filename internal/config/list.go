@@ -0,0 +1,71 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ParseList parses a list setting. Drone passes YAML lists either as a comma
+// joined string or as a JSON array depending on its version, and users write
+// them by hand with spaces or one value per line, so all of these are
+// accepted. Values that only look like JSON, such as "[skip ci]", are split
+// like any other value. Values are trimmed and empty values dropped.
+func ParseList(raw string) []string {
+	raw = strings.TrimSpace(raw)
+	values := []string{}
+
+	if strings.HasPrefix(raw, "[") {
+		decoded := []string{}
+		if err := json.Unmarshal([]byte(raw), &decoded); err == nil {
+			return appendTrimmed(values, decoded...)
+		}
+	}
+
+	return appendTrimmed(values, strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})...)
+}
+
+func appendTrimmed(values []string, raw ...string) []string {
+	for _, value := range raw {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getList reads the list setting key. Environment variables hold strings,
+// while policy files may hold a list, or a mapping for "key=value" lists.
+func getList(v *viper.Viper, key string) []string {
+	switch value := v.Get(key).(type) {
+	case nil:
+		return []string{}
+	case []string:
+		return appendTrimmed([]string{}, value...)
+	case map[string]interface{}:
+		names := []string{}
+		for name := range value {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		values := []string{}
+		for _, name := range names {
+			values = appendTrimmed(values, fmt.Sprintf("%s=%v", name, value[name]))
+		}
+		return values
+	case []interface{}:
+		values := []string{}
+		for _, item := range value {
+			values = appendTrimmed(values, fmt.Sprint(item))
+		}
+		return values
+	default:
+		return ParseList(fmt.Sprint(value))
+	}
+}
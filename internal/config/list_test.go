@@ -0,0 +1,31 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{
+		{name: "ParseListEmpty", raw: "", want: []string{}},
+		{name: "ParseListComma", raw: "feat:,fix:", want: []string{"feat:", "fix:"}},
+		{name: "ParseListCommaWithSpaces", raw: "feat:, fix: ,", want: []string{"feat:", "fix:"}},
+		{name: "ParseListNewlines", raw: "feat:\n fix:\r\n\n", want: []string{"feat:", "fix:"}},
+		{name: "ParseListJSON", raw: ` ["feat:", " fix:", ""]`, want: []string{"feat:", "fix:"}},
+		{name: "ParseListJSONWithCommas", raw: `["## Summary, notes"]`, want: []string{"## Summary, notes"}},
+		{name: "ParseListInvalidJSON", raw: `["feat:"`, want: []string{`["feat:"`}},
+		{name: "ParseListBrackets", raw: "[feat],[fix]", want: []string{"[feat]", "[fix]"}},
+		{name: "ParseListBracketed", raw: "[skip ci]", want: []string{"[skip ci]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseList(tt.raw); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseList() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import "github.com/spf13/viper"
+
+// options change how the configuration is loaded.
+type options struct {
+	// overrides replace the environment, keyed like the variables.
+	overrides map[string]interface{}
+	// settingsOnly skips the checks for pull request details and
+	// credentials, to validate settings outside of a pipeline.
+	settingsOnly bool
+	// offline refuses to read remote policies.
+	offline bool
+}
+
+// Option configures New.
+type Option func(*options)
+
+// PullRequest holds the pull request details Drone passes to the plugin.
+type PullRequest struct {
+	Owner        string
+	Repo         string
+	Number       int
+	Title        string
+	SourceBranch string
+	TargetBranch string
+}
+
+// WithPullRequest checks pr instead of the pull request of the pipeline.
+func WithPullRequest(pr PullRequest) Option {
+	return func(o *options) {
+		o.set(owner, pr.Owner)
+		o.set(repo, pr.Repo)
+		o.set(pullRequest, pr.Number)
+		o.set(title, pr.Title)
+		o.set(sourceBranch, pr.SourceBranch)
+		o.set(targetBranch, pr.TargetBranch)
+	}
+}
+
+// WithGitHubToken authenticates with token instead of GITHUB_TOKEN.
+func WithGitHubToken(token string) Option {
+	return func(o *options) {
+		o.set(githubToken, token)
+	}
+}
+
+// WithPolicy loads the policy file at file instead of PLUGIN_POLICY.
+func WithPolicy(file string) Option {
+	return func(o *options) {
+		o.set(policy, file)
+	}
+}
+
+// SettingsOnly validates the settings without requiring pull request
+// details or credentials.
+func SettingsOnly() Option {
+	return func(o *options) {
+		o.settingsOnly = true
+	}
+}
+
+// DryRun enables dry run mode, like PLUGIN_DRY_RUN.
+func DryRun() Option {
+	return func(o *options) {
+		o.set(dryRun, true)
+	}
+}
+
+// Offline loads the configuration without network access, failing on
+// policies that extend a remote policy.
+func Offline() Option {
+	return func(o *options) {
+		o.offline = true
+	}
+}
+
+func (o *options) set(key string, value interface{}) {
+	if o.overrides == nil {
+		o.overrides = map[string]interface{}{}
+	}
+	o.overrides[key] = value
+}
+
+// apply sets the overrides, which take precedence over the environment.
+func (o *options) apply(v *viper.Viper) {
+	for key, value := range o.overrides {
+		v.Set(key, value)
+	}
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/github"
+	"gopkg.in/yaml.v3"
+)
+
+// extendsKey names the policy a policy file builds on.
+const extendsKey = "extends"
+
+// remotePolicyRe matches policies kept in another repository, given as
+// "owner/repo:path/to/policy.yml" with an optional "@ref".
+var remotePolicyRe = regexp.MustCompile(`^([\w.-]+)/([\w.-]+):([^@]+)(?:@(.+))?$`)
+
+// contentFetcher reads files from GitHub repositories.
+type contentFetcher interface {
+	GetContents(owner string, repo string, path string, ref string) ([]byte, error)
+}
+
+// newFetcher returns the client used to read remote policies.
+var newFetcher = func(token string) contentFetcher {
+	return github.New(token)
+}
+
+// policySource is the location of a policy file, either in the workspace or,
+// when owner is set, in a GitHub repository.
+type policySource struct {
+	owner string
+	repo  string
+	path  string
+	ref   string
+}
+
+func (s policySource) String() string {
+	if s.owner == "" {
+		return s.path
+	}
+	if s.ref == "" {
+		return fmt.Sprintf("%s/%s:%s", s.owner, s.repo, s.path)
+	}
+	return fmt.Sprintf("%s/%s:%s@%s", s.owner, s.repo, s.path, s.ref)
+}
+
+// resolve returns the source of a policy extended by s. Relative paths are
+// resolved against the directory of s, in the same repository and ref for
+// remote policies.
+func (s policySource) resolve(extends string) policySource {
+	if match := remotePolicyRe.FindStringSubmatch(extends); match != nil {
+		return policySource{owner: match[1], repo: match[2], path: strings.TrimPrefix(match[3], "/"), ref: match[4]}
+	}
+	if s.owner != "" {
+		return policySource{owner: s.owner, repo: s.repo, path: path.Join(path.Dir(s.path), extends), ref: s.ref}
+	}
+	if filepath.IsAbs(extends) {
+		return policySource{path: extends}
+	}
+	return policySource{path: filepath.Join(filepath.Dir(s.path), extends)}
+}
+
+// policyLoader loads policy files and the policies they extend.
+type policyLoader struct {
+	token   string
+	offline bool
+	fetcher contentFetcher
+}
+
+func (l *policyLoader) read(source policySource) ([]byte, error) {
+	if source.owner == "" {
+		return os.ReadFile(source.path)
+	}
+	if l.offline {
+		return nil, fmt.Errorf("remote policies cannot be read offline")
+	}
+	if l.fetcher == nil {
+		l.fetcher = newFetcher(l.token)
+	}
+	return l.fetcher.GetContents(source.owner, source.repo, source.path, source.ref)
+}
+
+// load returns the settings of the policy at source merged over the policies
+// it extends. Settings of the extending policy replace the same settings of
+// the extended one as a whole, lists and objects included, so the result does
+// not depend on anything but the files. chain holds the policies that led to
+// source and is used to detect cycles.
+func (l *policyLoader) load(source policySource, chain []string) (map[string]interface{}, error) {
+	for i, seen := range chain {
+		if seen == source.String() {
+			cycle := append(slices.Clone(chain[i:]), seen)
+			return nil, fmt.Errorf("%s: policies extend each other: %s", env(policy), strings.Join(cycle, " -> "))
+		}
+	}
+	chain = append(chain, source.String())
+
+	content, err := l.read(source)
+	if err != nil {
+		return nil, fmt.Errorf("%s: reading %s: %w", env(policy), source, err)
+	}
+
+	document := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("%s: parsing %s: %w", env(policy), source, err)
+	}
+
+	settings := map[string]interface{}{}
+	if extends, ok := document[extendsKey]; ok {
+		delete(document, extendsKey)
+		base, ok := extends.(string)
+		if !ok || base == "" {
+			return nil, fmt.Errorf("%s: %s: %s must be a policy path", env(policy), source, extendsKey)
+		}
+		if settings, err = l.load(source.resolve(base), chain); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, value := range document {
+		if _, ok := policySettings()[name]; !ok {
+			return nil, fmt.Errorf("%s: %s: unknown setting %q", env(policy), source, name)
+		}
+		settings[name] = value
+	}
+
+	return settings, nil
+}
+
+// loadPolicy loads the policy file at file, which may extend others. Offline,
+// only policies in the workspace can be read.
+func loadPolicy(file string, token string, offline bool) (map[string]interface{}, error) {
+	loader := &policyLoader{token: token, offline: offline}
+	return loader.load(policySource{path: file}, nil)
+}
+
+// policySettings maps the setting names used in policy files, which are the
+// names of the Drone settings, to their keys. Only plugin settings can be
+// given in a policy, credentials and pull request details cannot.
+func policySettings() map[string]string {
+	names := map[string]string{}
+	for _, key := range envVars {
+		if !strings.HasPrefix(key, "plugin_") || key == policy {
+			continue
+		}
+		names[settingName(key)] = key
+	}
+	return names
+}
+
+// settingName returns the Drone setting name of a key, e.g. skipOnLabels for
+// plugin_skip_on_labels.
+func settingName(key string) string {
+	words := strings.Split(strings.TrimPrefix(key, "plugin_"), "_")
+	for i := 1; i < len(words); i++ {
+		words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+	}
+	return strings.Join(words, "")
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testFetcher serves remote policies keyed by "owner/repo:path@ref".
+type testFetcher map[string]string
+
+func (f testFetcher) GetContents(owner string, repo string, path string, ref string) ([]byte, error) {
+	content, ok := f[fmt.Sprintf("%s/%s:%s@%s", owner, repo, path, ref)]
+	if !ok {
+		return nil, fmt.Errorf("%s/%s:%s@%s not found", owner, repo, path, ref)
+	}
+	return []byte(content), nil
+}
+
+func writePolicies(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func setPolicyEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	for _, key := range envVars {
+		t.Setenv(strings.ToUpper(key), "")
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	remote := testFetcher{
+		"acme/policies:drone/base.yml@v1": `
+extends: common.yml
+minApprovals: 1
+requiredLabels: [reviewed]
+`,
+		"acme/policies:drone/common.yml@v1": `
+preset: conventional-commits
+typeLabels:
+  feat: enhancement
+checklists:
+  - title: "## Security review"
+    required: true
+`,
+	}
+	restore := newFetcher
+	newFetcher = func(token string) contentFetcher { return remote }
+	t.Cleanup(func() { newFetcher = restore })
+
+	dir := writePolicies(t, map[string]string{
+		".github/pr-checker.yml": `
+extends: acme/policies:drone/base.yml@v1
+minApprovals: 2
+freezeWindows: [Sat-Sun]
+`,
+	})
+
+	setPolicyEnv(t, map[string]string{
+		"PLUGIN_POLICY":            filepath.Join(dir, ".github/pr-checker.yml"),
+		"PLUGIN_REQUIRED_LABELS":   "reviewed,qa",
+		"DRONE_PULL_REQUEST_TITLE": "feat: shared policies",
+		"DRONE_TARGET_BRANCH":      "main",
+		"GITHUB_TOKEN":             "token",
+		"DRONE_REPO_OWNER":         "octocat",
+		"DRONE_REPO_NAME":          "hello-world",
+		"DRONE_PULL_REQUEST":       "1",
+	})
+
+	cfg, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	settings := cfg.Settings
+	if settings.MinApprovals != 2 {
+		t.Errorf("MinApprovals = %d, want 2 from the repository policy", settings.MinApprovals)
+	}
+	if !reflect.DeepEqual(settings.RequiredLabels, []string{"reviewed", "qa"}) {
+		t.Errorf("RequiredLabels = %q, want the environment to win", settings.RequiredLabels)
+	}
+	if settings.Regexp != conventionalTitle || !reflect.DeepEqual(settings.TypeLabels, map[string]string{"feat": "enhancement"}) {
+		t.Errorf("Regexp = %q, TypeLabels = %v, want the preset and mapping of the shared policy", settings.Regexp, settings.TypeLabels)
+	}
+	if !reflect.DeepEqual(settings.Checklists, []Checklist{{Title: "## Security review", Required: true}}) {
+		t.Errorf("Checklists = %v, want the shared checklist", settings.Checklists)
+	}
+	if len(settings.FreezeWindows) != 1 || settings.FreezeWindows[0].Spec != "Sat-Sun" {
+		t.Errorf("FreezeWindows = %v, want Sat-Sun", settings.FreezeWindows)
+	}
+
+	want := map[string]interface{}{
+		"preset":         "conventional-commits",
+		"typeLabels":     map[string]interface{}{"feat": "enhancement"},
+		"checklists":     []interface{}{map[string]interface{}{"title": "## Security review", "required": true}},
+		"minApprovals":   2,
+		"requiredLabels": []interface{}{"reviewed"},
+		"freezeWindows":  []interface{}{"Sat-Sun"},
+	}
+	if !reflect.DeepEqual(cfg.Policy, want) {
+		t.Errorf("Policy = %v, want %v", cfg.Policy, want)
+	}
+}
+
+func TestNewPolicyErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name: "NewPolicyCycle",
+			files: map[string]string{
+				"policy.yml":      "extends: shared/base.yml\n",
+				"shared/base.yml": "extends: ../policy.yml\n",
+			},
+			wantErr: "PLUGIN_POLICY: policies extend each other: {dir}/policy.yml -> {dir}/shared/base.yml -> {dir}/policy.yml",
+		},
+		{
+			name:    "NewPolicyUnknownSetting",
+			files:   map[string]string{"policy.yml": "githubToken: secret\n"},
+			wantErr: `PLUGIN_POLICY: {dir}/policy.yml: unknown setting "githubToken"`,
+		},
+		{
+			name:    "NewPolicyMissingBase",
+			files:   map[string]string{"policy.yml": "extends: base.yml\n"},
+			wantErr: "PLUGIN_POLICY: reading {dir}/base.yml: open {dir}/base.yml: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writePolicies(t, tt.files)
+			setPolicyEnv(t, map[string]string{"PLUGIN_POLICY": filepath.Join(dir, "policy.yml")})
+
+			_, err := New()
+			if want := strings.ReplaceAll(tt.wantErr, "{dir}", dir); err == nil || err.Error() != want {
+				t.Errorf("New() error = %v, want %s", err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// conventionalTitle matches "type(scope)!: subject" titles, the allowed
+// types are checked separately.
+const conventionalTitle = `^[a-z]+(\([\w ./-]+\))?!?: \S`
+
+// presets are named sets of settings shared by many repositories. They are
+// applied as defaults, so any setting the user gives wins.
+var presets = map[string]map[string]string{
+	"conventional-commits": {
+		titleRegexp: conventionalTitle,
+		types:       "feat,fix,docs,style,refactor,perf,test,build,ci,chore,revert",
+		typeLabels:  "feat=enhancement,fix=bug,docs=documentation",
+	},
+	"angular": {
+		titleRegexp: conventionalTitle,
+		types:       "build,ci,docs,feat,fix,perf,refactor,test",
+		typeLabels:  "feat=enhancement,fix=bug,docs=documentation",
+	},
+	"semantic-release": {
+		titleRegexp: conventionalTitle,
+		types:       "feat,fix,perf,docs,style,refactor,test,build,ci,chore,revert",
+		typeLabels:  "feat=minor,fix=patch,perf=patch",
+	},
+	"gitmoji": {
+		titleRegexp: `^:[a-z0-9_+-]+: \S`,
+		prefixes: ":art:,:zap:,:fire:,:bug:,:ambulance:,:sparkles:,:memo:,:rocket:,:lipstick:," +
+			":tada:,:white_check_mark:,:lock:,:bookmark:,:rotating_light:,:construction:,:green_heart:," +
+			":arrow_down:,:arrow_up:,:pushpin:,:construction_worker:,:recycle:,:heavy_plus_sign:," +
+			":heavy_minus_sign:,:wrench:,:globe_with_meridians:,:pencil2:,:rewind:,:truck:,:boom:," +
+			":wheelchair:,:bulb:,:card_file_box:,:label:,:alembic:,:passport_control:,:adhesive_bandage:",
+	},
+}
+
+// presetNames returns the names of the built-in presets in order.
+func presetNames() []string {
+	names := []string{}
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// applyPreset sets the values of the selected preset as defaults. Unknown
+// presets are reported by validate.
+func applyPreset(v *viper.Viper) {
+	for key, value := range presets[v.GetString(preset)] {
+		v.SetDefault(key, value)
+	}
+}
+
+// parseTypeLabels parses "type=label" entries. An entry with an empty label
+// drops the mapping for that type, e.g. to clear one set by a preset.
+func parseTypeLabels(entries []string) (map[string]string, error) {
+	mapping := map[string]string{}
+	for _, entry := range entries {
+		prType, label, found := strings.Cut(entry, "=")
+		prType, label = strings.TrimSpace(prType), strings.TrimSpace(label)
+		if !found || prType == "" {
+			return nil, fmt.Errorf("%s: entry %q must be given as type=label", env(typeLabels), entry)
+		}
+		if label == "" {
+			delete(mapping, strings.ToLower(prType))
+			continue
+		}
+		mapping[strings.ToLower(prType)] = label
+	}
+	return mapping, nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewPreset(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		regexp     string
+		prefixes   []string
+		types      []string
+		typeLabels map[string]string
+	}{
+		{
+			name:       "NewPresetAngular",
+			env:        map[string]string{"PLUGIN_PRESET": "angular"},
+			regexp:     conventionalTitle,
+			prefixes:   []string{},
+			types:      []string{"build", "ci", "docs", "feat", "fix", "perf", "refactor", "test"},
+			typeLabels: map[string]string{"feat": "enhancement", "fix": "bug", "docs": "documentation"},
+		},
+		{
+			name: "NewPresetOverridden",
+			env: map[string]string{
+				"PLUGIN_PRESET":      "conventional-commits",
+				"PLUGIN_TYPES":       "feat, fix",
+				"PLUGIN_TYPE_LABELS": "Feat=feature,fix=",
+			},
+			regexp:     conventionalTitle,
+			prefixes:   []string{},
+			types:      []string{"feat", "fix"},
+			typeLabels: map[string]string{"feat": "feature"},
+		},
+		{
+			name:       "NewPresetGitmoji",
+			env:        map[string]string{"PLUGIN_PRESET": "gitmoji", "PLUGIN_REGEXP": "^:[a-z_]+: [A-Z]"},
+			regexp:     "^:[a-z_]+: [A-Z]",
+			prefixes:   strings.Split(presets["gitmoji"][prefixes], ","),
+			types:      []string{},
+			typeLabels: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range envVars {
+				t.Setenv(strings.ToUpper(key), "")
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			t.Setenv("DRONE_PULL_REQUEST_TITLE", "feat: add presets")
+			t.Setenv("GITHUB_TOKEN", "token")
+			t.Setenv("DRONE_REPO_OWNER", "octocat")
+			t.Setenv("DRONE_REPO_NAME", "hello-world")
+			t.Setenv("DRONE_PULL_REQUEST", "1")
+
+			cfg, err := New()
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			settings := cfg.Settings
+			if settings.Regexp != tt.regexp ||
+				!reflect.DeepEqual(settings.Prefixes, tt.prefixes) ||
+				!reflect.DeepEqual(settings.Types, tt.types) ||
+				!reflect.DeepEqual(settings.TypeLabels, tt.typeLabels) {
+				t.Errorf(
+					"New() = regexp %q, prefixes %q, types %q, type labels %v, want %q, %q, %q, %v",
+					settings.Regexp, settings.Prefixes, settings.Types, settings.TypeLabels,
+					tt.regexp, tt.prefixes, tt.types, tt.typeLabels,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/glob"
+	"github.com/spf13/viper"
+)
+
+// parseProfiles decodes profiles, keyed by target branch glob, from the
+// JSON encoded profiles setting or, when that is not set, from the policy.
+func parseProfiles(v *viper.Viper, fromPolicy interface{}) (map[string]map[string]interface{}, error) {
+	raw := v.GetString(profiles)
+	if raw == "" && fromPolicy != nil {
+		encoded, err := json.Marshal(fromPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", env(profiles), err)
+		}
+		raw = string(encoded)
+	}
+
+	decoded := map[string]map[string]interface{}{}
+	if raw == "" {
+		return decoded, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		return nil, fmt.Errorf("%s must map target branch globs to settings: %w", env(profiles), err)
+	}
+
+	keys := policySettings()
+	for pattern, settings := range decoded {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%s: invalid target branch pattern %q", env(profiles), pattern)
+		}
+		for name := range settings {
+			if key, ok := keys[name]; !ok || key == profiles {
+				return nil, fmt.Errorf("%s: profile %q: unknown setting %q", env(profiles), pattern, name)
+			}
+		}
+	}
+
+	return decoded, nil
+}
+
+// applyProfiles overrides settings with the profiles matching the target
+// branch and returns their patterns. Profiles are applied from the shortest
+// pattern to the longest, so "release/1.*" overrides "release/*".
+func applyProfiles(v *viper.Viper, branchProfiles map[string]map[string]interface{}) []string {
+	branch := v.GetString(targetBranch)
+	applied := []string{}
+	if branch == "" {
+		return applied
+	}
+
+	for pattern := range branchProfiles {
+		if glob.Match(pattern, branch) {
+			applied = append(applied, pattern)
+		}
+	}
+	slices.SortFunc(applied, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+		return strings.Compare(a, b)
+	})
+
+	keys := policySettings()
+	for _, pattern := range applied {
+		for name, value := range branchProfiles[pattern] {
+			v.Set(keys[name], value)
+		}
+	}
+
+	return applied
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewProfiles(t *testing.T) {
+	dir := writePolicies(t, map[string]string{
+		"policy.yml": `
+prefixes: [feat:, fix:, chore:]
+minApprovals: 1
+profiles:
+  release/*:
+    prefixes: [fix:, hotfix:]
+    changelog: [CHANGELOG.md]
+    minApprovals: 2
+  release/1.x:
+    minApprovals: 3
+  develop:
+    prefixes: []
+`,
+	})
+
+	tests := []struct {
+		name         string
+		env          map[string]string
+		profiles     []string
+		prefixes     []string
+		changelog    []string
+		minApprovals int
+	}{
+		{
+			name:         "NewProfilesNoMatch",
+			env:          map[string]string{"DRONE_TARGET_BRANCH": "main"},
+			profiles:     []string{},
+			prefixes:     []string{"feat:", "fix:", "chore:"},
+			changelog:    []string{},
+			minApprovals: 1,
+		},
+		{
+			name:         "NewProfilesRelease",
+			env:          map[string]string{"DRONE_TARGET_BRANCH": "release/2.0"},
+			profiles:     []string{"release/*"},
+			prefixes:     []string{"fix:", "hotfix:"},
+			changelog:    []string{"CHANGELOG.md"},
+			minApprovals: 2,
+		},
+		{
+			name:         "NewProfilesMostSpecificWins",
+			env:          map[string]string{"DRONE_TARGET_BRANCH": "release/1.x", "PLUGIN_MIN_APPROVALS": "5"},
+			profiles:     []string{"release/*", "release/1.x"},
+			prefixes:     []string{"fix:", "hotfix:"},
+			changelog:    []string{"CHANGELOG.md"},
+			minApprovals: 3,
+		},
+		{
+			name:         "NewProfilesLenient",
+			env:          map[string]string{"DRONE_TARGET_BRANCH": "develop"},
+			profiles:     []string{"develop"},
+			prefixes:     []string{},
+			changelog:    []string{},
+			minApprovals: 1,
+		},
+		{
+			name: "NewProfilesFromEnvironment",
+			env: map[string]string{
+				"DRONE_TARGET_BRANCH": "develop",
+				"PLUGIN_PROFILES":     `{"dev*": {"minApprovals": 0}}`,
+			},
+			profiles:     []string{"dev*"},
+			prefixes:     []string{"feat:", "fix:", "chore:"},
+			changelog:    []string{},
+			minApprovals: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setPolicyEnv(t, tt.env)
+			t.Setenv("PLUGIN_POLICY", filepath.Join(dir, "policy.yml"))
+			t.Setenv("DRONE_PULL_REQUEST_TITLE", "fix: backport")
+			t.Setenv("GITHUB_TOKEN", "token")
+			t.Setenv("DRONE_REPO_OWNER", "octocat")
+			t.Setenv("DRONE_REPO_NAME", "hello-world")
+			t.Setenv("DRONE_PULL_REQUEST", "1")
+
+			cfg, err := New()
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			settings := cfg.Settings
+			if !reflect.DeepEqual(cfg.Profiles, tt.profiles) ||
+				!reflect.DeepEqual(settings.Prefixes, tt.prefixes) ||
+				!reflect.DeepEqual(settings.Changelog, tt.changelog) ||
+				settings.MinApprovals != tt.minApprovals {
+				t.Errorf(
+					"New() = profiles %q, prefixes %q, changelog %q, %d approvals, want %q, %q, %q, %d",
+					cfg.Profiles, settings.Prefixes, settings.Changelog, settings.MinApprovals,
+					tt.profiles, tt.prefixes, tt.changelog, tt.minApprovals,
+				)
+			}
+		})
+	}
+}
+
+func TestNewProfilesUnknownSetting(t *testing.T) {
+	setPolicyEnv(t, map[string]string{
+		"DRONE_TARGET_BRANCH": "main",
+		"PLUGIN_PROFILES":     `{"main": {"githubToken": "secret"}}`,
+	})
+
+	want := `PLUGIN_PROFILES: profile "main": unknown setting "githubToken"`
+	if _, err := New(); err == nil || err.Error() != want {
+		t.Errorf("New() error = %v, want %s", err, want)
+	}
+}
@@ -1,23 +1,143 @@
 package config
 
+import "time"
+
 type Config struct {
 	Settings Settings
 	Github   GitHub
+	Tracker  Tracker
+	// Policy holds the settings of the policy file merged with the policies
+	// it extends, keyed by setting name.
+	Policy map[string]interface{}
+	// Profiles lists the target branch profiles that were applied.
+	Profiles []string
+	// Values holds every setting with the source of its value, in the
+	// order of the environment variables.
+	Values []Value
+	// Problems holds the validation errors of a dry run, which reports them
+	// instead of failing.
+	Problems []error
 }
 
 type GitHub struct {
-	Token string `validate:"required"`
+	Token string
+}
+
+// Tracker holds the optional external issue tracker used to verify Jira or
+// Linear issue keys. URL is a template containing "{key}".
+type Tracker struct {
+	URL   string
+	Token string
 }
 
 type Settings struct {
-	Prefixes          string `validate:"required"`
-	Regexp            string `validate:"required"`
-	SkipOnLabels      string `validate:"required"`
-	IgnoreGitHubError bool   `validate:"required"`
-	Checklist         bool   `validate:"required"`
-	Title             string `validate:"required"`
-	ChecklistTitle    string `validate:"required"`
-	Repo              string `validate:"required"`
-	Owner             string `validate:"required"`
-	PullRequest       int    `validate:"required"`
+	Preset            string
+	DryRun            bool
+	Prefixes          []string
+	Types             []string
+	Changelog         []string
+	TypeLabels        map[string]string
+	Regexp            string
+	SkipOnLabels      []string
+	IgnoreGitHubError bool
+	Checklist         bool
+	Title             string
+	ChecklistTitle    string
+	ChecklistTitleRe  bool
+	Repo              string
+	Owner             string
+	PullRequest       int
+	ChecklistOptional []string
+	ChecklistTemplate bool
+	Checklists        []Checklist
+	RequiredLabels    []string
+	Rules             []Rule
+	Codeowners        bool
+	CodeownersFile    string
+	MinApprovals      int
+	DismissStale      bool
+	ApproverTeam      string
+	ApproverTeamMode  string
+	Draft             string
+	Mergeable         bool
+	MaxBehind         int
+	MergeableRetries  int
+	MergeableInterval time.Duration
+	FreezeBranches    []string
+	FreezeWindows     []FreezeWindow
+	FreezeOverride    string
+	FreezeSeverity    string
+	SignedCommits     bool
+	CommitHygiene     bool
+	MaxCommits        int
+	BranchRegexp      string
+	BranchTargets     []string
+	SourceBranch      string
+	TargetBranch      string
+	IssueReference    bool
+	IssueKeys         []string
+	IssueVerify       bool
+	RequiredSections  []string
+	SectionMinLength  int
+	Template          string
+}
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Draft modes decide how draft and work in progress pull requests are handled.
+const (
+	// DraftRun checks drafts like any other pull request.
+	DraftRun = "run"
+	// DraftSkip skips all checks for drafts.
+	DraftSkip = "skip"
+	// DraftFail fails drafts.
+	DraftFail = "fail"
+	// DraftWarn runs all checks but only reports failures as warnings.
+	DraftWarn = "warn"
+)
+
+const (
+	// ApproverTeamDouble counts approvals from the approver team twice.
+	ApproverTeamDouble = "double"
+	// ApproverTeamMandatory requires at least one approval from the team.
+	ApproverTeamMandatory = "mandatory"
+)
+
+// Checklist is a named checklist section of the PR description, configured
+// through the JSON encoded checklists setting.
+type Checklist struct {
+	// Title is the heading of the section, e.g. "## Security review".
+	Title string `json:"title"`
+	// TitleRegexp matches Title as a regular expression against the heading
+	// text instead of literally.
+	TitleRegexp bool `json:"titleRegexp"`
+	// Optional lists item texts that may stay unchecked.
+	Optional []string `json:"optional"`
+	// Severity is either "error" (default) or "warning".
+	Severity string `json:"severity"`
+	// Required makes a missing section an error.
+	Required bool `json:"required"`
+	// Template requires the items of the same section in the PR template.
+	Template bool `json:"template"`
+}
+
+// Rule applies extra policy to pull requests touching matching paths. It is
+// configured through the JSON encoded rules setting.
+type Rule struct {
+	// Paths are globs matched against the changed files, "**" matches any
+	// number of directories.
+	Paths []string `json:"paths"`
+	// Only applies the rule when every changed file matches, e.g. for
+	// documentation only pull requests. By default one matching file is
+	// enough.
+	Only bool `json:"only"`
+	// Checklists are titles of checklist sections that become required.
+	Checklists []string `json:"checklists"`
+	// Labels the pull request must carry.
+	Labels []string `json:"labels"`
+	// Skip lists the ids of checks to skip, e.g. "commits".
+	Skip []string `json:"skip"`
 }
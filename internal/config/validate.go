@@ -0,0 +1,291 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// requirement describes a check for validation: the settings that enable it,
+// the settings it needs while it is enabled and whether it talks to the
+// GitHub API.
+type requirement struct {
+	check   string
+	by      []string
+	enabled func(s Settings) bool
+	needs   []string
+	api     bool
+}
+
+// apiSettings are needed by every check that reads the pull request from the
+// GitHub API.
+var apiSettings = []string{githubToken, owner, repo, pullRequest}
+
+var requirements = []requirement{
+	{
+		check:   "prefix",
+		by:      []string{prefixes},
+		enabled: func(s Settings) bool { return len(s.Prefixes) > 0 },
+		needs:   []string{title},
+	},
+	{
+		check:   "type",
+		by:      []string{types},
+		enabled: func(s Settings) bool { return len(s.Types) > 0 },
+		needs:   []string{title},
+	},
+	{
+		check:   "type labels",
+		by:      []string{types, typeLabels},
+		enabled: func(s Settings) bool { return len(s.Types) > 0 && len(s.TypeLabels) > 0 },
+		api:     true,
+	},
+	{
+		check:   "regexp",
+		by:      []string{titleRegexp},
+		enabled: func(s Settings) bool { return s.Regexp != "" },
+		needs:   []string{title},
+	},
+	{
+		check:   "branch",
+		by:      []string{branchRegexp},
+		enabled: func(s Settings) bool { return s.BranchRegexp != "" },
+		needs:   []string{sourceBranch},
+	},
+	{
+		check:   "target",
+		by:      []string{branchTargets},
+		enabled: func(s Settings) bool { return len(s.BranchTargets) > 0 },
+		needs:   []string{sourceBranch, targetBranch},
+	},
+	{
+		check:   "labels",
+		by:      []string{skipOnLabels},
+		enabled: func(s Settings) bool { return len(s.SkipOnLabels) > 0 },
+		api:     true,
+	},
+	{
+		check:   "draft",
+		by:      []string{draft},
+		enabled: func(s Settings) bool { return s.Draft != DraftRun },
+		api:     true,
+	},
+	{
+		check:   "rules",
+		by:      []string{rules},
+		enabled: func(s Settings) bool { return len(s.Rules) > 0 },
+		api:     true,
+	},
+	{
+		check:   "required-labels",
+		by:      []string{requiredLabels},
+		enabled: func(s Settings) bool { return len(s.RequiredLabels) > 0 },
+		api:     true,
+	},
+	{
+		check:   "issue",
+		by:      []string{issueReference},
+		enabled: func(s Settings) bool { return s.IssueReference },
+		api:     true,
+	},
+	{
+		check:   "sections",
+		by:      []string{requiredSections},
+		enabled: func(s Settings) bool { return len(s.RequiredSections) > 0 },
+		api:     true,
+	},
+	{
+		check:   "checklist",
+		by:      []string{checklist},
+		enabled: func(s Settings) bool { return s.Checklist },
+		needs:   []string{checklistTitle},
+		api:     true,
+	},
+	{
+		check:   "checklists",
+		by:      []string{checklists},
+		enabled: func(s Settings) bool { return len(s.Checklists) > 0 },
+		api:     true,
+	},
+	{
+		check:   "changelog",
+		by:      []string{changelog},
+		enabled: func(s Settings) bool { return len(s.Changelog) > 0 },
+		api:     true,
+	},
+	{
+		check:   "signatures",
+		by:      []string{signedCommits},
+		enabled: func(s Settings) bool { return s.SignedCommits },
+		api:     true,
+	},
+	{
+		check:   "commits",
+		by:      []string{commitHygiene, maxCommits},
+		enabled: func(s Settings) bool { return s.CommitHygiene || s.MaxCommits > 0 },
+		api:     true,
+	},
+	{
+		check:   "codeowners",
+		by:      []string{codeowners},
+		enabled: func(s Settings) bool { return s.Codeowners },
+		api:     true,
+	},
+	{
+		check:   "reviews",
+		by:      []string{minApprovals},
+		enabled: func(s Settings) bool { return s.MinApprovals > 0 },
+		api:     true,
+	},
+	{
+		check:   "mergeable",
+		by:      []string{mergeable},
+		enabled: func(s Settings) bool { return s.Mergeable },
+		api:     true,
+	},
+	{
+		check:   "freeze",
+		by:      []string{freezeWindows},
+		enabled: func(s Settings) bool { return len(s.FreezeWindows) > 0 },
+		needs:   []string{targetBranch},
+	},
+	{
+		check:   "freeze override",
+		by:      []string{freezeWindows, freezeOverride},
+		enabled: func(s Settings) bool { return len(s.FreezeWindows) > 0 && s.FreezeOverride != "" },
+		api:     true,
+	},
+}
+
+// env returns the environment variable a setting is read from.
+func env(key string) string {
+	return strings.ToUpper(key)
+}
+
+// isSet reports which settings hold a value, keyed like the variables.
+func (config *Config) isSet() map[string]bool {
+	return map[string]bool{
+		githubToken:    config.Github.Token != "",
+		owner:          config.Settings.Owner != "",
+		repo:           config.Settings.Repo != "",
+		pullRequest:    config.Settings.PullRequest > 0,
+		title:          config.Settings.Title != "",
+		sourceBranch:   config.Settings.SourceBranch != "",
+		targetBranch:   config.Settings.TargetBranch != "",
+		checklistTitle: config.Settings.ChecklistTitle != "",
+	}
+}
+
+// validate checks that every enabled check has the settings it needs and
+// that enumerated settings hold a known value. All problems are reported at
+// once, naming the variable to set. With settingsOnly, the pull request
+// details and credentials checks need are not required. Dry runs keep the
+// problems in Problems rather than failing, so they can still be explained.
+func (config *Config) validate(settingsOnly bool) (*Config, error) {
+	errs := []error{}
+	set := config.isSet()
+	reported := map[string]bool{}
+
+	for _, requirement := range requirements {
+		if settingsOnly || !requirement.enabled(config.Settings) {
+			continue
+		}
+		needs := requirement.needs
+		if requirement.api {
+			needs = append(slices.Clone(needs), apiSettings...)
+		}
+		for _, key := range needs {
+			if set[key] || reported[key] {
+				continue
+			}
+			reported[key] = true
+			errs = append(errs, fmt.Errorf("%s is required when the %s check is enabled", env(key), requirement.check))
+		}
+	}
+
+	errs = append(errs,
+		oneOf(draft, config.Settings.Draft, DraftRun, DraftSkip, DraftFail, DraftWarn),
+		oneOf(approverTeamMode, config.Settings.ApproverTeamMode, ApproverTeamDouble, ApproverTeamMandatory),
+		oneOf(freezeSeverity, config.Settings.FreezeSeverity, SeverityError, SeverityWarning),
+	)
+
+	if config.Settings.Preset != "" {
+		errs = append(errs, oneOf(preset, config.Settings.Preset, presetNames()...))
+	}
+
+	errs = append(errs, config.validatePatterns()...)
+
+	if team := config.Settings.ApproverTeam; team != "" && !strings.Contains(team, "/") {
+		errs = append(errs, fmt.Errorf("%s must be given as org/team, got %q", env(approverTeam), team))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		if !config.Settings.DryRun {
+			return nil, err
+		}
+		for _, err := range errs {
+			if err != nil {
+				config.Problems = append(config.Problems, err)
+			}
+		}
+	}
+	return config, nil
+}
+
+func oneOf(key string, value string, allowed ...string) error {
+	if slices.Contains(allowed, value) {
+		return nil
+	}
+	return fmt.Errorf("%s must be one of %s, got %q", env(key), strings.Join(allowed, ", "), value)
+}
+
+// validatePatterns compiles every user supplied pattern so that a typo is
+// reported at startup rather than when the check runs.
+func (config *Config) validatePatterns() []error {
+	settings := config.Settings
+	errs := []error{
+		compiles(titleRegexp, settings.Regexp),
+		compiles(branchRegexp, settings.BranchRegexp),
+	}
+
+	if settings.Checklist && settings.ChecklistTitleRe {
+		errs = append(errs, compiles(checklistTitle, settings.ChecklistTitle))
+	}
+
+	for _, section := range settings.Checklists {
+		if !section.TitleRegexp {
+			continue
+		}
+		if _, err := regexp.Compile(section.Title); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid title pattern %q: %w", env(checklists), section.Title, err))
+		}
+	}
+
+	for _, rule := range settings.BranchTargets {
+		target, source, found := strings.Cut(rule, "=")
+		if !found || target == "" || source == "" {
+			errs = append(errs, fmt.Errorf("%s: rule %q must be given as target=source", env(branchTargets), rule))
+			continue
+		}
+		for _, glob := range []string{target, source} {
+			if _, err := path.Match(glob, ""); err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid pattern %q in rule %q", env(branchTargets), glob, rule))
+			}
+		}
+	}
+
+	return errs
+}
+
+func compiles(key string, pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("%s is not a valid regular expression: %w", env(key), err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// woodpeckerVars maps the pull request details Drone passes to the
+// variables Woodpecker passes them in, in order of preference. Woodpecker
+// forked Drone and passes step settings as PLUGIN_* variables as well, so
+// only these differ.
+var woodpeckerVars = map[string][]string{
+	title:        {"CI_COMMIT_TITLE", "CI_COMMIT_MESSAGE"},
+	repo:         {"CI_REPO_NAME"},
+	owner:        {"CI_REPO_OWNER"},
+	pullRequest:  {"CI_COMMIT_PULL_REQUEST"},
+	sourceBranch: {"CI_COMMIT_SOURCE_BRANCH"},
+	targetBranch: {"CI_COMMIT_TARGET_BRANCH"},
+}
+
+// woodpecker reports whether the plugin runs in a Woodpecker pipeline.
+func woodpecker() bool {
+	return os.Getenv("CI") == "woodpecker"
+}
+
+// woodpeckerEnv returns the pull request details of a Woodpecker pipeline
+// that are not given in the Drone variables, keyed like them. Outside of
+// Woodpecker it returns nothing.
+func woodpeckerEnv() map[string]string {
+	values := map[string]string{}
+	if !woodpecker() {
+		return values
+	}
+
+	for key, names := range woodpeckerVars {
+		if os.Getenv(env(key)) != "" {
+			continue
+		}
+		for _, name := range names {
+			value := os.Getenv(name)
+			// Titles only hold the subject of a commit message.
+			if key == title {
+				value, _, _ = strings.Cut(strings.TrimSpace(value), "\n")
+			}
+			if value != "" {
+				values[key] = strings.TrimSpace(value)
+				break
+			}
+		}
+	}
+	return values
+}
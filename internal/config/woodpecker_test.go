@@ -0,0 +1,76 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewWoodpecker(t *testing.T) {
+	type pullRequest struct {
+		owner, repo, title, source, target string
+		number                             int
+	}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want pullRequest
+	}{
+		{
+			name: "NewWoodpecker",
+			env:  map[string]string{},
+			want: pullRequest{"octocat", "hello-world", "feat: add woodpecker", "feat/woodpecker", "main", 7},
+		},
+		{
+			name: "NewWoodpeckerCommitTitle",
+			env:  map[string]string{"CI_COMMIT_TITLE": "fix: prefer the title"},
+			want: pullRequest{"octocat", "hello-world", "fix: prefer the title", "feat/woodpecker", "main", 7},
+		},
+		{
+			name: "NewWoodpeckerDroneVariables",
+			env:  map[string]string{"DRONE_PULL_REQUEST_TITLE": "chore: from drone", "DRONE_PULL_REQUEST": "8"},
+			want: pullRequest{"octocat", "hello-world", "chore: from drone", "feat/woodpecker", "main", 8},
+		},
+		{
+			name: "NewWoodpeckerNotDetected",
+			env:  map[string]string{"CI": "drone"},
+			want: pullRequest{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range envVars {
+				t.Setenv(strings.ToUpper(key), "")
+			}
+			t.Setenv("CI", "woodpecker")
+			t.Setenv("CI_REPO_OWNER", "octocat")
+			t.Setenv("CI_REPO_NAME", "hello-world")
+			t.Setenv("CI_COMMIT_PULL_REQUEST", "7")
+			t.Setenv("CI_COMMIT_TITLE", "")
+			t.Setenv("CI_COMMIT_MESSAGE", "feat: add woodpecker\n\nLonger description.")
+			t.Setenv("CI_COMMIT_SOURCE_BRANCH", "feat/woodpecker")
+			t.Setenv("CI_COMMIT_TARGET_BRANCH", "main")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			t.Setenv("GITHUB_TOKEN", "token")
+
+			cfg, err := New()
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			s := cfg.Settings
+			got := pullRequest{s.Owner, s.Repo, s.Title, s.SourceBranch, s.TargetBranch, s.PullRequest}
+			if got != tt.want {
+				t.Errorf("New() = %+v, want %+v", got, tt.want)
+			}
+
+			for _, value := range cfg.Values {
+				if value.Env == "DRONE_PULL_REQUEST_TITLE" && value.Value != "" && value.Source != SourceEnv {
+					t.Errorf("title source = %s, want %s", value.Source, SourceEnv)
+				}
+			}
+		})
+	}
+}
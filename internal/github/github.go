@@ -2,12 +2,21 @@ package github
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/google/go-github/v61/github"
 )
 
 type GitHub struct {
 	client *github.Client
+	// commits caches pull request commit listings so that every commit based
+	// check shares a single round of API calls.
+	commits map[string][]*github.RepositoryCommit
+	files   map[string][]*github.CommitFile
+	reviews map[string][]*github.PullRequestReview
 }
 
 func (g *GitHub) GetPullRequest(owner string, repo string, number int) (*github.PullRequest, error) {
@@ -15,8 +24,146 @@ func (g *GitHub) GetPullRequest(owner string, repo string, number int) (*github.
 	return pr, err
 }
 
+func (g *GitHub) ListCommits(owner string, repo string, number int) ([]*github.RepositoryCommit, error) {
+	key := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+	if commits, ok := g.commits[key]; ok {
+		return commits, nil
+	}
+
+	commits := []*github.RepositoryCommit{}
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		page, resp, err := g.client.PullRequests.ListCommits(context.Background(), owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, page...)
+		if resp.NextPage == 0 {
+			g.commits[key] = commits
+			return commits, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (g *GitHub) ListFiles(owner string, repo string, number int) ([]*github.CommitFile, error) {
+	key := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+	if files, ok := g.files[key]; ok {
+		return files, nil
+	}
+
+	files := []*github.CommitFile{}
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		page, resp, err := g.client.PullRequests.ListFiles(context.Background(), owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, page...)
+		if resp.NextPage == 0 {
+			g.files[key] = files
+			return files, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (g *GitHub) ListReviews(owner string, repo string, number int) ([]*github.PullRequestReview, error) {
+	key := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+	if reviews, ok := g.reviews[key]; ok {
+		return reviews, nil
+	}
+
+	reviews := []*github.PullRequestReview{}
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		page, resp, err := g.client.PullRequests.ListReviews(context.Background(), owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, page...)
+		if resp.NextPage == 0 {
+			g.reviews[key] = reviews
+			return reviews, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// IsTeamMember reports whether user is an active member of the org/team.
+func (g *GitHub) IsTeamMember(org string, team string, user string) (bool, error) {
+	membership, _, err := g.client.Teams.GetTeamMembershipBySlug(context.Background(), org, team, user)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return membership.GetState() == "active", nil
+}
+
+func (g *GitHub) CompareCommits(owner string, repo string, base string, head string) (*github.CommitsComparison, error) {
+	comparison, _, err := g.client.Repositories.CompareCommits(context.Background(), owner, repo, base, head, &github.ListOptions{PerPage: 1})
+	return comparison, err
+}
+
+func (g *GitHub) GetIssue(owner string, repo string, number int) (*github.Issue, error) {
+	issue, _, err := g.client.Issues.Get(context.Background(), owner, repo, number)
+	return issue, err
+}
+
+// GetContents returns the content of the file at path, read at ref or at the
+// default branch when ref is empty.
+func (g *GitHub) GetContents(owner string, repo string, path string, ref string) ([]byte, error) {
+	file, _, _, err := g.client.Repositories.GetContents(
+		context.Background(),
+		owner,
+		repo,
+		path,
+		&github.RepositoryContentGetOptions{Ref: ref},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+// LatestReviews returns the latest review of every reviewer that approved,
+// requested changes or got their review dismissed, keyed by login. Plain
+// comments do not change a reviewer's state.
+func LatestReviews(reviews []*github.PullRequestReview) map[string]*github.PullRequestReview {
+	latest := map[string]*github.PullRequestReview{}
+	for _, review := range reviews {
+		switch review.GetState() {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			latest[strings.ToLower(review.GetUser().GetLogin())] = review
+		}
+	}
+	return latest
+}
+
+// IsNotFound reports whether err is a GitHub API 404 response.
+func IsNotFound(err error) bool {
+	var resp *github.ErrorResponse
+	return errors.As(err, &resp) && resp.Response != nil && resp.Response.StatusCode == http.StatusNotFound
+}
+
 func New(token string) GitHubInterface {
 	return &GitHub{
-		client: github.NewClient(nil).WithAuthToken(token),
+		client:  github.NewClient(nil).WithAuthToken(token),
+		commits: map[string][]*github.RepositoryCommit{},
+		files:   map[string][]*github.CommitFile{},
+		reviews: map[string][]*github.PullRequestReview{},
 	}
 }
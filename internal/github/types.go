@@ -4,4 +4,11 @@ import "github.com/google/go-github/v61/github"
 
 type GitHubInterface interface {
 	GetPullRequest(owner string, repo string, number int) (*github.PullRequest, error)
+	ListCommits(owner string, repo string, number int) ([]*github.RepositoryCommit, error)
+	ListFiles(owner string, repo string, number int) ([]*github.CommitFile, error)
+	ListReviews(owner string, repo string, number int) ([]*github.PullRequestReview, error)
+	IsTeamMember(org string, team string, user string) (bool, error)
+	CompareCommits(owner string, repo string, base string, head string) (*github.CommitsComparison, error)
+	GetIssue(owner string, repo string, number int) (*github.Issue, error)
+	GetContents(owner string, repo string, path string, ref string) ([]byte, error)
 }
@@ -0,0 +1,45 @@
+// Package glob matches slash separated paths against glob patterns. It
+// extends path.Match with "**", which matches any number of path segments.
+package glob
+
+import (
+	"path"
+	"strings"
+)
+
+// Match reports whether name matches pattern. Each pattern segment is
+// matched with path.Match, except "**" which matches zero or more segments.
+func Match(pattern string, name string) bool {
+	return match(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+// MatchAny reports whether name matches any of the patterns.
+func MatchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if Match(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func match(pattern []string, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if match(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,27 @@
+package glob
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "infra/**", name: "infra/terraform/main.tf", want: true},
+		{pattern: "infra/**", name: "infra", want: true},
+		{pattern: "infra/**", name: "docs/infra/main.tf", want: false},
+		{pattern: "**/*.md", name: "README.md", want: true},
+		{pattern: "**/*.md", name: "docs/guide/intro.md", want: true},
+		{pattern: "docs/*.md", name: "docs/guide/intro.md", want: false},
+		{pattern: "src/**/test/*.go", name: "src/a/b/test/x.go", want: true},
+		{pattern: "release/*", name: "release/1.2", want: true},
+		{pattern: "release/*", name: "release", want: false},
+		{pattern: "[", name: "[", want: false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
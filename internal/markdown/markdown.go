@@ -0,0 +1,198 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	headingRe = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	fenceRe   = regexp.MustCompile("^ {0,3}(```|~~~)")
+	commentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+	itemRe    = regexp.MustCompile(`^([ \t]*)(?:[-*+]|\d{1,9}[.)])(?:[ \t]+(.*))?$`)
+	taskRe    = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+(.*))?$`)
+)
+
+// Parse splits body into sections. Headings inside fenced code blocks and
+// HTML comments are ignored and Windows line endings are normalised.
+func Parse(body string) Document {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	doc := Document{}
+	open := []int{}
+	fence := ""
+	comment := false
+
+	for _, line := range lines {
+		commented := comment
+		if fence == "" {
+			comment = inComment(line, comment)
+		}
+
+		// Fences and headings inside comments are hidden, so the lines only
+		// belong to the open sections.
+		if commented {
+			for _, i := range open {
+				doc.Sections[i].Lines = append(doc.Sections[i].Lines, line)
+			}
+			continue
+		}
+
+		if match := fenceRe.FindStringSubmatch(line); match != nil {
+			switch fence {
+			case "":
+				fence = match[1]
+			case match[1]:
+				fence = ""
+			}
+		} else if fence == "" {
+			if match := headingRe.FindStringSubmatch(line); match != nil {
+				level := len(match[1])
+				// Close every open section this heading terminates.
+				for len(open) > 0 && doc.Sections[open[len(open)-1]].Level >= level {
+					open = open[:len(open)-1]
+				}
+				for _, i := range open {
+					doc.Sections[i].Lines = append(doc.Sections[i].Lines, line)
+				}
+				doc.Sections = append(doc.Sections, Section{Level: level, Title: strings.TrimSpace(match[2])})
+				open = append(open, len(doc.Sections)-1)
+				continue
+			}
+		}
+
+		for _, i := range open {
+			doc.Sections[i].Lines = append(doc.Sections[i].Lines, line)
+		}
+	}
+
+	return doc
+}
+
+// inComment reports whether an HTML comment is still open after line, given
+// whether one was open before it.
+func inComment(line string, open bool) bool {
+	for {
+		marker := "<!--"
+		if open {
+			marker = "-->"
+		}
+		i := strings.Index(line, marker)
+		if i < 0 {
+			return open
+		}
+		line = line[i+len(marker):]
+		open = !open
+	}
+}
+
+// Find returns the first section whose heading matches heading. The heading
+// may be given with its leading hashes ("## Checklist"), in which case the
+// level must match too. Titles are compared case-insensitively.
+func (d Document) Find(heading string) (Section, bool) {
+	level, title := splitHeading(heading)
+	for _, section := range d.Sections {
+		if level > 0 && section.Level != level {
+			continue
+		}
+		if strings.EqualFold(section.Title, title) {
+			return section, true
+		}
+	}
+	return Section{}, false
+}
+
+// FindMatch returns the first section whose heading text, without the
+// leading hashes, matches re.
+func (d Document) FindMatch(re *regexp.Regexp) (Section, bool) {
+	for _, section := range d.Sections {
+		if re.MatchString(section.Title) {
+			return section, true
+		}
+	}
+	return Section{}, false
+}
+
+// Text returns the section content.
+func (s Section) Text() string {
+	return strings.Join(s.Lines, "\n")
+}
+
+// Tasks returns the task list items of the section as a tree following the
+// list indentation. Plain list items are not returned, but tasks nested
+// under them are attached to the closest task above.
+func (s Section) Tasks() []Task {
+	type open struct {
+		indent int
+		task   *Task
+	}
+
+	root := &Task{}
+	stack := []open{{indent: -1, task: root}}
+	fence := ""
+
+	for _, line := range strings.Split(StripComments(s.Text()), "\n") {
+		if match := fenceRe.FindStringSubmatch(line); match != nil {
+			switch fence {
+			case "":
+				fence = match[1]
+			case match[1]:
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		item := itemRe.FindStringSubmatch(line)
+		if item == nil {
+			continue
+		}
+
+		indent := len(strings.ReplaceAll(item[1], "\t", "    "))
+		for stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		task := taskRe.FindStringSubmatch(strings.TrimSpace(item[2]))
+		if task == nil {
+			continue
+		}
+
+		parent := stack[len(stack)-1].task
+		parent.Children = append(parent.Children, Task{
+			Text:    strings.TrimSpace(task[2]),
+			Checked: task[1] != " ",
+		})
+		stack = append(stack, open{indent: indent, task: &parent.Children[len(parent.Children)-1]})
+	}
+
+	return root.Children
+}
+
+// Struck reports whether the whole task text is struck through ("~~text~~"),
+// which authors use to mark an item as not applicable.
+func (t Task) Struck() bool {
+	return len(t.Text) > 4 && strings.HasPrefix(t.Text, "~~") && strings.HasSuffix(t.Text, "~~")
+}
+
+// Flatten returns tasks and all their nested tasks in document order.
+func Flatten(tasks []Task) []Task {
+	flat := []Task{}
+	for _, task := range tasks {
+		flat = append(flat, task)
+		flat = append(flat, Flatten(task.Children)...)
+	}
+	return flat
+}
+
+// StripComments removes HTML comments, which PR templates use for hints.
+func StripComments(text string) string {
+	return commentRe.ReplaceAllString(text, "")
+}
+
+func splitHeading(heading string) (int, string) {
+	heading = strings.TrimSpace(heading)
+	level := len(heading) - len(strings.TrimLeft(heading, "#"))
+	return level, strings.TrimSpace(heading[level:])
+}
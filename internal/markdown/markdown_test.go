@@ -0,0 +1,100 @@
+package markdown
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	body := "# Title\r\nintro\r\n## Summary\r\nDoes things\r\n### Details\r\nmore\r\n```\r\n## not a heading\r\n```\r\n## Testing ##\r\nran it"
+
+	want := Document{Sections: []Section{
+		{Level: 1, Title: "Title", Lines: []string{
+			"intro", "## Summary", "Does things", "### Details", "more", "```", "## not a heading", "```", "## Testing ##", "ran it",
+		}},
+		{Level: 2, Title: "Summary", Lines: []string{"Does things", "### Details", "more", "```", "## not a heading", "```"}},
+		{Level: 3, Title: "Details", Lines: []string{"more", "```", "## not a heading", "```"}},
+		{Level: 2, Title: "Testing", Lines: []string{"ran it"}},
+	}}
+
+	if got := Parse(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %#v, want %#v", got, want)
+	}
+}
+
+func TestParseComments(t *testing.T) {
+	body := "<!--\n## Checklist\n- [x] fake\n-->\n## Checklist\n<!-- hints\n## Example\n-->\n- [ ] real"
+
+	want := Document{Sections: []Section{
+		{Level: 2, Title: "Checklist", Lines: []string{"<!-- hints", "## Example", "-->", "- [ ] real"}},
+	}}
+
+	got := Parse(body)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %#v, want %#v", got, want)
+	}
+
+	section, _ := got.Find("## Checklist")
+	if tasks := section.Tasks(); !reflect.DeepEqual(tasks, []Task{{Text: "real"}}) {
+		t.Errorf("Section.Tasks() = %#v, want the real task only", tasks)
+	}
+}
+
+func TestDocument_Find(t *testing.T) {
+	doc := Parse("# Checklist\n## checklist\ntwo")
+
+	tests := []struct {
+		name    string
+		heading string
+		level   int
+		found   bool
+	}{
+		{name: "FindWithLevel", heading: "## Checklist", level: 2, found: true},
+		{name: "FindWithoutLevel", heading: "Checklist", level: 1, found: true},
+		{name: "FindMissing", heading: "### Checklist", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section, found := doc.Find(tt.heading)
+			if found != tt.found || section.Level != tt.level {
+				t.Errorf("Document.Find(%q) = %v, %v, want level %d, %v", tt.heading, section, found, tt.level, tt.found)
+			}
+		})
+	}
+}
+
+func TestDocument_FindMatch(t *testing.T) {
+	doc := Parse("# Overview\n## Author checklist\none")
+
+	section, found := doc.FindMatch(regexp.MustCompile(`checklist$`))
+	if !found || section.Title != "Author checklist" {
+		t.Errorf("Document.FindMatch() = %v, %v, want Author checklist", section, found)
+	}
+	if _, found := doc.FindMatch(regexp.MustCompile(`^Checklist`)); found {
+		t.Errorf("Document.FindMatch() found a section, want none")
+	}
+}
+
+func TestSection_Tasks(t *testing.T) {
+	doc := Parse("## Checklist\r\n- [x] Tests\r\n* [X] Docs\r\n+ [ ] Review\r\n  - [ ] Security\r\n  - Notes\r\n    1. [ ] Nested under a plain item\r\n- Not a task\r\n<!-- - [ ] commented out -->\r\n```\r\n- [ ] in code\r\n```\r\n- [ ]\r\n")
+	section, _ := doc.Find("## Checklist")
+
+	want := []Task{
+		{Text: "Tests", Checked: true},
+		{Text: "Docs", Checked: true},
+		{Text: "Review", Children: []Task{
+			{Text: "Security"},
+			{Text: "Nested under a plain item"},
+		}},
+		{Text: ""},
+	}
+
+	if got := section.Tasks(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Section.Tasks() = %#v, want %#v", got, want)
+	}
+
+	if got := len(Flatten(want)); got != 6 {
+		t.Errorf("Flatten() returned %d tasks, want 6", got)
+	}
+}
@@ -0,0 +1,23 @@
+package markdown
+
+// Document is a PR description split into its heading delimited sections.
+type Document struct {
+	Sections []Section
+}
+
+// Section is the content under an ATX heading. Lines holds everything up to
+// the next heading of the same or a higher level, so nested sub-sections are
+// part of their parent.
+type Section struct {
+	Level int
+	Title string
+	Lines []string
+}
+
+// Task is a task list item ("- [ ] text"). Children holds the task items
+// nested under it.
+type Task struct {
+	Text     string
+	Checked  bool
+	Children []Task
+}
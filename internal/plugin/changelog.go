@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/glob"
+)
+
+// checkPRChangelog requires the pull request to change at least one file
+// matching the Changelog globs.
+func (prc *PullRequestChecker) checkPRChangelog() *PullRequestChecker {
+
+	if len(prc.settings.Changelog) == 0 {
+		prc.steps = append(prc.steps, Step{status: Skip, message: ChangelogSkipMsg, id: ChangelogStepID})
+		return prc
+	}
+
+	files, err := prc.github.ListFiles(
+		prc.settings.Owner,
+		prc.settings.Repo,
+		prc.settings.PullRequest,
+	)
+
+	if err != nil {
+		return prc.githubError(ChangelogStepID, err)
+	}
+
+	for _, file := range files {
+		// Deleting the changelog is not an entry.
+		if file.GetStatus() != "removed" && glob.MatchAny(prc.settings.Changelog, file.GetFilename()) {
+			prc.steps = append(prc.steps, Step{status: Success, message: ChangelogSuccesMsg, id: ChangelogStepID})
+			return prc
+		}
+	}
+
+	prc.steps = append(
+		prc.steps,
+		Step{
+			status:  Err,
+			message: fmt.Sprintf(ChangelogErrMsg, strings.Join(prc.settings.Changelog, ", ")),
+			id:      ChangelogStepID,
+		},
+	)
+	prc.errors++
+	return prc
+}
@@ -0,0 +1,71 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+func TestPullRequestChecker_CheckPRChangelog(t *testing.T) {
+	changelog := []string{"CHANGELOG.md", "changes/**/*.md"}
+
+	tests := []struct {
+		name     string
+		settings config.Settings
+		files    []*github.CommitFile
+		err      error
+		want     []Step
+		errors   int
+	}{
+		{
+			name:     "CheckPRChangelogDisabled",
+			settings: config.Settings{},
+			want:     []Step{{status: Skip, message: ChangelogSkipMsg, id: ChangelogStepID}},
+		},
+		{
+			name:     "CheckPRChangelogEntryAdded",
+			settings: config.Settings{Changelog: changelog},
+			files: []*github.CommitFile{
+				{Filename: github.String("main.go"), Status: github.String("modified")},
+				{Filename: github.String("changes/1.2/fix-panic.md"), Status: github.String("added")},
+			},
+			want: []Step{{status: Success, message: ChangelogSuccesMsg, id: ChangelogStepID}},
+		},
+		{
+			name:     "CheckPRChangelogMissing",
+			settings: config.Settings{Changelog: changelog},
+			files: []*github.CommitFile{
+				{Filename: github.String("main.go"), Status: github.String("modified")},
+				{Filename: github.String("CHANGELOG.md"), Status: github.String("removed")},
+			},
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(ChangelogErrMsg, "CHANGELOG.md, changes/**/*.md"),
+				id:      ChangelogStepID,
+			}},
+			errors: 1,
+		},
+		{
+			name:     "CheckPRChangelogGitHubError",
+			settings: config.Settings{Changelog: changelog, IgnoreGitHubError: true},
+			err:      errors.New("Error"),
+			want:     []Step{{status: Skip, message: "Error", id: ChangelogStepID}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.settings,
+				github:   &TestGithubClient{files: tt.files, err: tt.err},
+			}
+			got := prc.checkPRChangelog()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors {
+				t.Errorf("PullRequestChecker.CheckPRChangelog() = %v (%d errors), want %v (%d errors)", got.steps, got.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
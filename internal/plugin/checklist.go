@@ -0,0 +1,203 @@
+package plugin
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/config"
+	"github.com/nyambati/drone-pr-checker/internal/markdown"
+)
+
+// optionalSuffix marks a checklist item that does not have to be ticked.
+const optionalSuffix = "(optional)"
+
+// checklist is a checklist section together with the id of its step.
+type checklist struct {
+	id string
+	config.Checklist
+}
+
+// checklists returns the checklist sections to validate. The single
+// checklist configured through Checklist and ChecklistTitle keeps the plain
+// "checklist" step id, named sections get their title appended.
+func (prc *PullRequestChecker) checklists() []checklist {
+	checklists := []checklist{}
+
+	if prc.settings.Checklist {
+		checklists = append(checklists, checklist{
+			id: ChecklistStepID,
+			Checklist: config.Checklist{
+				Title:       prc.settings.ChecklistTitle,
+				TitleRegexp: prc.settings.ChecklistTitleRe,
+				Optional:    prc.settings.ChecklistOptional,
+				Template:    prc.settings.ChecklistTemplate,
+			},
+		})
+	}
+
+	for _, section := range prc.settings.Checklists {
+		checklists = append(checklists, checklist{
+			id:        fmt.Sprintf("%s[%s]", ChecklistStepID, section.Title),
+			Checklist: section,
+		})
+	}
+
+	return checklists
+}
+
+func (prc *PullRequestChecker) checkPRChecklist() *PullRequestChecker {
+
+	checklists := prc.checklists()
+
+	if len(checklists) == 0 {
+		prc.steps = append(prc.steps, Step{status: Skip, message: ChecklistSkipMsg, id: ChecklistStepID})
+		return prc
+	}
+
+	pr, err := prc.github.GetPullRequest(
+		prc.settings.Owner,
+		prc.settings.Repo,
+		prc.settings.PullRequest,
+	)
+
+	if err != nil {
+		return prc.githubError(ChecklistStepID, err)
+	}
+
+	body := markdown.Parse(pr.GetBody())
+
+	template, hasTemplate, err := prc.template()
+	if err != nil {
+		prc.steps = append(prc.steps, Step{status: Err, message: err.Error(), id: ChecklistStepID})
+		prc.errors++
+		return prc
+	}
+
+	for _, checklist := range checklists {
+		// Without the template, deleted sections and items go unnoticed.
+		if checklist.Template && !hasTemplate {
+			prc.steps = append(prc.steps, Step{
+				status:  Err,
+				message: fmt.Sprintf(ChecklistTemplateErrMsg, prc.settings.Template),
+				id:      checklist.id,
+			})
+			prc.errors++
+			continue
+		}
+		prc.checkChecklistSection(checklist, body, template)
+	}
+
+	return prc
+}
+
+func (prc *PullRequestChecker) checkChecklistSection(
+	checklist checklist,
+	body markdown.Document,
+	template markdown.Document,
+) *PullRequestChecker {
+
+	id := checklist.id
+
+	find := func(doc markdown.Document) (markdown.Section, bool) {
+		return doc.Find(checklist.Title)
+	}
+	if checklist.TitleRegexp {
+		re, err := regexp.Compile(checklist.Title)
+		if err != nil {
+			return prc.fail(id, fmt.Sprintf(PatternErrMsg, err), checklist.Severity)
+		}
+		find = func(doc markdown.Document) (markdown.Section, bool) {
+			return doc.FindMatch(re)
+		}
+	}
+
+	section, found := find(body)
+	optional := map[string]bool{}
+	for _, item := range checklist.Optional {
+		optional[normaliseTask(item)] = true
+	}
+
+	if checklist.Template {
+		if expected, ok := find(template); ok {
+			if !found {
+				return prc.fail(id, fmt.Sprintf(ChecklistMissingMsg, checklist.Title), checklist.Severity)
+			}
+
+			if removed := removedTasks(expected.Tasks(), section.Tasks(), optional); len(removed) > 0 {
+				return prc.fail(
+					id,
+					fmt.Sprintf(ChecklistRemovedMsg, len(removed), strings.Join(removed, ", ")),
+					checklist.Severity,
+				)
+			}
+		}
+	}
+
+	if !found && checklist.Required {
+		return prc.fail(id, fmt.Sprintf(ChecklistRequiredMsg, checklist.Title), checklist.Severity)
+	}
+
+	if found {
+		unchecked := requiredUnchecked(section.Tasks(), optional, false)
+
+		if len(unchecked) > 0 {
+			return prc.fail(
+				id,
+				fmt.Sprintf(ChecklistErrMsg, len(unchecked), strings.Join(unchecked, ", ")),
+				checklist.Severity,
+			)
+		}
+	}
+
+	prc.steps = append(prc.steps, Step{status: Success, message: ChecklistSuccesMsg, id: id})
+	return prc
+}
+
+// requiredUnchecked returns the quoted text of every unchecked task that is
+// neither optional nor struck through. Optional and struck through tasks
+// exempt the tasks nested under them as well.
+func requiredUnchecked(tasks []markdown.Task, optional map[string]bool, exempt bool) []string {
+	unchecked := []string{}
+	for _, task := range tasks {
+		taskExempt := exempt ||
+			task.Struck() ||
+			optional[normaliseTask(task.Text)] ||
+			strings.HasSuffix(strings.ToLower(task.Text), optionalSuffix)
+		if !task.Checked && !taskExempt {
+			unchecked = append(unchecked, fmt.Sprintf("%q", task.Text))
+		}
+		unchecked = append(unchecked, requiredUnchecked(task.Children, optional, taskExempt)...)
+	}
+	return unchecked
+}
+
+// removedTasks returns the quoted text of every required template task that
+// is missing from the PR checklist, i.e. was deleted or reworded instead of
+// being ticked.
+func removedTasks(expected []markdown.Task, actual []markdown.Task, optional map[string]bool) []string {
+	present := map[string]bool{}
+	for _, task := range markdown.Flatten(actual) {
+		present[normaliseTask(task.Text)] = true
+	}
+
+	removed := []string{}
+	for _, task := range markdown.Flatten(expected) {
+		text := normaliseTask(task.Text)
+		if present[text] || optional[text] || strings.HasSuffix(strings.ToLower(task.Text), optionalSuffix) {
+			continue
+		}
+		removed = append(removed, fmt.Sprintf("%q", task.Text))
+	}
+	return removed
+}
+
+// normaliseTask makes checklist item texts comparable regardless of case,
+// surrounding whitespace, strike through and an optional marker.
+func normaliseTask(text string) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if len(text) > 4 && strings.HasPrefix(text, "~~") && strings.HasSuffix(text, "~~") {
+		text = strings.TrimSpace(text[2 : len(text)-2])
+	}
+	return strings.TrimSpace(strings.TrimSuffix(text, optionalSuffix))
+}
@@ -0,0 +1,149 @@
+package plugin
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+func TestPullRequestChecker_CheckPRChecklistSections(t *testing.T) {
+	body := `
+## Author checklist
+- [x] Added tests
+- [ ] Updated docs
+
+## Security review
+- [x] Threat model reviewed
+- [ ] Secrets rotated (optional)
+`
+
+	author := config.Checklist{Title: "## Author checklist"}
+	security := config.Checklist{Title: "## Security review", Required: true, Severity: config.SeverityWarning}
+	compliance := config.Checklist{Title: "## Compliance", Required: true}
+
+	tests := []struct {
+		name     string
+		settings config.Settings
+		want     []Step
+		errors   int
+	}{
+		{
+			name:     "CheckPRChecklistSectionsIndependentSeverities",
+			settings: config.Settings{Checklists: []config.Checklist{author, security}},
+			want: []Step{
+				{status: Err, message: fmt.Sprintf(ChecklistErrMsg, 1, `"Updated docs"`), id: "checklist[## Author checklist]"},
+				{status: Success, message: ChecklistSuccesMsg, id: "checklist[## Security review]"},
+			},
+			errors: 1,
+		},
+		{
+			name: "CheckPRChecklistSectionsMissingRequiredSection",
+			settings: config.Settings{Checklists: []config.Checklist{
+				{Title: "## Author checklist", Optional: []string{"Updated docs"}},
+				compliance,
+				{Title: "## Sign-off", Required: true, Severity: config.SeverityWarning},
+				{Title: "## Optional extras"},
+			}},
+			want: []Step{
+				{status: Success, message: ChecklistSuccesMsg, id: "checklist[## Author checklist]"},
+				{status: Err, message: fmt.Sprintf(ChecklistRequiredMsg, "## Compliance"), id: "checklist[## Compliance]"},
+				{status: Warn, message: fmt.Sprintf(ChecklistRequiredMsg, "## Sign-off"), id: "checklist[## Sign-off]"},
+				{status: Success, message: ChecklistSuccesMsg, id: "checklist[## Optional extras]"},
+			},
+			errors: 1,
+		},
+		{
+			name: "CheckPRChecklistSectionsWithLegacyChecklist",
+			settings: config.Settings{
+				Checklist:      true,
+				ChecklistTitle: "## Security review",
+				Checklists:     []config.Checklist{{Title: "## Author checklist", Severity: config.SeverityWarning}},
+			},
+			want: []Step{
+				{status: Success, message: ChecklistSuccesMsg, id: ChecklistStepID},
+				{status: Warn, message: fmt.Sprintf(ChecklistErrMsg, 1, `"Updated docs"`), id: "checklist[## Author checklist]"},
+			},
+		},
+		{
+			name: "CheckPRChecklistSectionsTitleRegexp",
+			settings: config.Settings{
+				Checklist:        true,
+				ChecklistTitle:   `(?i)^author (check)?list$`,
+				ChecklistTitleRe: true,
+				Checklists:       []config.Checklist{{Title: `^Security`, TitleRegexp: true, Required: true}},
+			},
+			want: []Step{
+				{status: Err, message: fmt.Sprintf(ChecklistErrMsg, 1, `"Updated docs"`), id: ChecklistStepID},
+				{status: Success, message: ChecklistSuccesMsg, id: "checklist[^Security]"},
+			},
+			errors: 1,
+		},
+		{
+			name: "CheckPRChecklistSectionsLiteralTitle",
+			settings: config.Settings{
+				Checklists: []config.Checklist{{Title: "## Security review (team)", Required: true}},
+			},
+			want: []Step{
+				{
+					status:  Err,
+					message: fmt.Sprintf(ChecklistRequiredMsg, "## Security review (team)"),
+					id:      "checklist[## Security review (team)]",
+				},
+			},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.settings,
+				github:   &TestGithubClient{body: github.String(body)},
+			}
+			got := prc.checkPRChecklist()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors {
+				t.Errorf("PullRequestChecker.CheckPRChecklist() = %v (%d errors), want %v (%d errors)", got.steps, got.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
+
+func TestPullRequestChecker_CheckPRChecklistComments(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		want   []Step
+		errors int
+	}{
+		{
+			name: "CheckPRChecklistCommentedHeadingBefore",
+			body: "<!--\n## Checklist\n- [x] Added tests\n-->\n## Checklist\n- [ ] Added tests\n",
+			want: []Step{
+				{status: Err, message: fmt.Sprintf(ChecklistErrMsg, 1, `"Added tests"`), id: ChecklistStepID},
+			},
+			errors: 1,
+		},
+		{
+			name: "CheckPRChecklistCommentedHeadingInside",
+			body: "## Checklist\n- [x] Added tests\n<!--\n## Example\n-->\n- [ ] Updated docs\n",
+			want: []Step{
+				{status: Err, message: fmt.Sprintf(ChecklistErrMsg, 1, `"Updated docs"`), id: ChecklistStepID},
+			},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: config.Settings{Checklist: true, ChecklistTitle: "## Checklist"},
+				github:   &TestGithubClient{body: github.String(tt.body)},
+			}
+			got := prc.checkPRChecklist()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors {
+				t.Errorf("PullRequestChecker.CheckPRChecklist() = %v (%d errors), want %v (%d errors)", got.steps, got.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/codeowners"
+	"github.com/nyambati/drone-pr-checker/internal/github"
+)
+
+// codeowners reads the CODEOWNERS file from the workspace, either the
+// configured one or the first of the locations GitHub supports.
+func (prc *PullRequestChecker) codeowners() (codeowners.Ruleset, bool, error) {
+	locations := codeowners.Locations
+	if prc.settings.CodeownersFile != "" {
+		locations = []string{prc.settings.CodeownersFile}
+	}
+
+	for _, location := range locations {
+		content, err := os.ReadFile(location)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return codeowners.Parse(string(content)), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// byEmail reports whether owner is an email address rather than a "@user"
+// or "@org/team".
+func byEmail(owner string) bool {
+	return !strings.HasPrefix(owner, "@")
+}
+
+// approvedBy reports whether owner, a "@user" or "@org/team", is satisfied
+// by one of the approving logins. Team memberships are looked up once per
+// team and login. Owners given by email address cannot be matched to a
+// login and never approve.
+func (prc *PullRequestChecker) approvedBy(owner string, approvers []string, memberships map[string]bool) (bool, error) {
+	if byEmail(owner) {
+		return false, nil
+	}
+
+	owner = strings.ToLower(strings.TrimPrefix(owner, "@"))
+	org, team, isTeam := strings.Cut(owner, "/")
+
+	for _, login := range approvers {
+		if !isTeam {
+			if login == owner {
+				return true, nil
+			}
+			continue
+		}
+
+		key := owner + ":" + login
+		member, ok := memberships[key]
+		if !ok {
+			var err error
+			member, err = prc.github.IsTeamMember(org, team, login)
+			if err != nil {
+				return false, err
+			}
+			memberships[key] = member
+		}
+		if member {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (prc *PullRequestChecker) checkPRCodeowners() *PullRequestChecker {
+
+	if !prc.settings.Codeowners {
+		prc.steps = append(prc.steps, Step{status: Skip, message: CodeownersSkipMsg, id: CodeownersStepID})
+		return prc
+	}
+
+	ruleset, found, err := prc.codeowners()
+	if err != nil {
+		prc.steps = append(prc.steps, Step{status: Err, message: err.Error(), id: CodeownersStepID})
+		prc.errors++
+		return prc
+	}
+	if !found {
+		prc.steps = append(prc.steps, Step{status: Err, message: CodeownersNoFileMsg, id: CodeownersStepID})
+		prc.errors++
+		return prc
+	}
+
+	files, err := prc.github.ListFiles(prc.settings.Owner, prc.settings.Repo, prc.settings.PullRequest)
+	if err != nil {
+		return prc.githubError(CodeownersStepID, err)
+	}
+
+	reviews, err := prc.github.ListReviews(prc.settings.Owner, prc.settings.Repo, prc.settings.PullRequest)
+	if err != nil {
+		return prc.githubError(CodeownersStepID, err)
+	}
+
+	approvers := []string{}
+	for login, review := range github.LatestReviews(reviews) {
+		if review.GetState() == "APPROVED" {
+			approvers = append(approvers, login)
+		}
+	}
+	sort.Strings(approvers)
+
+	memberships := map[string]bool{}
+	missing := []string{}
+	unchecked := []string{}
+
+	for _, file := range files {
+		owners := ruleset.Owners(file.GetFilename())
+		if len(owners) == 0 {
+			continue
+		}
+
+		// Approvals come from logins, so owners known only by email
+		// address cannot be checked.
+		if !slices.ContainsFunc(owners, func(owner string) bool { return !byEmail(owner) }) {
+			unchecked = append(unchecked, fmt.Sprintf("%s (%s)", file.GetFilename(), strings.Join(owners, " ")))
+			continue
+		}
+
+		approved := false
+		for _, owner := range owners {
+			ok, err := prc.approvedBy(owner, approvers, memberships)
+			if err != nil {
+				return prc.githubError(CodeownersStepID, err)
+			}
+			if ok {
+				approved = true
+				break
+			}
+		}
+
+		if !approved {
+			missing = append(missing, fmt.Sprintf("%s (%s)", file.GetFilename(), strings.Join(owners, " ")))
+		}
+	}
+
+	if len(missing) > 0 {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(CodeownersErrMsg, len(missing), strings.Join(missing, ", ")),
+				id:      CodeownersStepID,
+			},
+		)
+		prc.errors++
+		return prc
+	}
+
+	if len(unchecked) > 0 {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Warn,
+				message: fmt.Sprintf(CodeownersEmailMsg, len(unchecked), strings.Join(unchecked, ", ")),
+				id:      CodeownersStepID,
+			},
+		)
+		return prc
+	}
+
+	prc.steps = append(prc.steps, Step{status: Success, message: CodeownersSuccesMsg, id: CodeownersStepID})
+	return prc
+}
@@ -0,0 +1,129 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+func TestPullRequestChecker_CheckPRCodeowners(t *testing.T) {
+	codeowners := filepath.Join(t.TempDir(), "CODEOWNERS")
+	err := os.WriteFile(codeowners, []byte("*.go @octocat\n/infra/ @org/platform\n/docs/\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emailOwners := filepath.Join(t.TempDir(), "CODEOWNERS")
+	err = os.WriteFile(emailOwners, []byte("*.go @octocat\n/infra/ @org/platform ops@example.com\n/docs/ docs@example.com\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := []*github.CommitFile{
+		{Filename: github.String("main.go")},
+		{Filename: github.String("infra/main.tf")},
+		{Filename: github.String("docs/index.md")},
+	}
+	review := func(login string, state string) *github.PullRequestReview {
+		return &github.PullRequestReview{User: &github.User{Login: github.String(login)}, State: github.String(state)}
+	}
+	teams := map[string][]string{"org/platform": {"hubot"}}
+
+	tests := []struct {
+		name     string
+		settings config.Settings
+		client   *TestGithubClient
+		want     []Step
+		errors   int
+	}{
+		{
+			name:     "CheckPRCodeownersDisabled",
+			settings: config.Settings{},
+			client:   &TestGithubClient{},
+			want:     []Step{{status: Skip, message: CodeownersSkipMsg, id: CodeownersStepID}},
+		},
+		{
+			name:     "CheckPRCodeownersNoFile",
+			settings: config.Settings{Codeowners: true, CodeownersFile: filepath.Join(t.TempDir(), "CODEOWNERS")},
+			client:   &TestGithubClient{},
+			want:     []Step{{status: Err, message: CodeownersNoFileMsg, id: CodeownersStepID}},
+			errors:   1,
+		},
+		{
+			name:     "CheckPRCodeownersApproved",
+			settings: config.Settings{Codeowners: true, CodeownersFile: codeowners},
+			client: &TestGithubClient{
+				files:   files,
+				reviews: []*github.PullRequestReview{review("OctoCat", "APPROVED"), review("hubot", "APPROVED")},
+				teams:   teams,
+			},
+			want: []Step{{status: Success, message: CodeownersSuccesMsg, id: CodeownersStepID}},
+		},
+		{
+			name:     "CheckPRCodeownersMissingApproval",
+			settings: config.Settings{Codeowners: true, CodeownersFile: codeowners},
+			client: &TestGithubClient{
+				files: files,
+				reviews: []*github.PullRequestReview{
+					review("octocat", "APPROVED"),
+					review("hubot", "APPROVED"),
+					review("hubot", "COMMENTED"),
+					review("hubot", "DISMISSED"),
+				},
+				teams: teams,
+			},
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(CodeownersErrMsg, 1, "infra/main.tf (@org/platform)"),
+				id:      CodeownersStepID,
+			}},
+			errors: 1,
+		},
+		{
+			name:     "CheckPRCodeownersEmailOwners",
+			settings: config.Settings{Codeowners: true, CodeownersFile: emailOwners},
+			client: &TestGithubClient{
+				files:   files,
+				reviews: []*github.PullRequestReview{review("octocat", "APPROVED"), review("hubot", "APPROVED")},
+				teams:   teams,
+			},
+			want: []Step{{
+				status:  Warn,
+				message: fmt.Sprintf(CodeownersEmailMsg, 1, "docs/index.md (docs@example.com)"),
+				id:      CodeownersStepID,
+			}},
+		},
+		{
+			name:     "CheckPRCodeownersEmailOwnersMissingApproval",
+			settings: config.Settings{Codeowners: true, CodeownersFile: emailOwners},
+			client: &TestGithubClient{
+				files:   files,
+				reviews: []*github.PullRequestReview{review("octocat", "APPROVED")},
+				teams:   teams,
+			},
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(CodeownersErrMsg, 1, "infra/main.tf (@org/platform ops@example.com)"),
+				id:      CodeownersStepID,
+			}},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.settings,
+				github:   tt.client,
+			}
+			got := prc.checkPRCodeowners()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors {
+				t.Errorf("PullRequestChecker.CheckPRCodeowners() = %v (%d errors), want %v (%d errors)", got.steps, got.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
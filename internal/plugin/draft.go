@@ -0,0 +1,46 @@
+package plugin
+
+import (
+	"regexp"
+
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+// wipTitleRe matches titles marking a pull request as work in progress.
+var wipTitleRe = regexp.MustCompile(`(?i)^\s*(\[wip\]|wip\b|draft:)`)
+
+func (prc *PullRequestChecker) checkPRDraft() *PullRequestChecker {
+
+	if prc.settings.Draft == "" || prc.settings.Draft == config.DraftRun {
+		prc.steps = append(prc.steps, Step{status: Skip, message: DraftSkipMsg, id: DraftStepID})
+		return prc
+	}
+
+	pr, err := prc.github.GetPullRequest(
+		prc.settings.Owner,
+		prc.settings.Repo,
+		prc.settings.PullRequest,
+	)
+
+	if err != nil {
+		return prc.githubError(DraftStepID, err)
+	}
+
+	if !pr.GetDraft() && !wipTitleRe.MatchString(prc.settings.Title) {
+		prc.steps = append(prc.steps, Step{status: Success, message: DraftSuccesMsg, id: DraftStepID})
+		return prc
+	}
+
+	switch prc.settings.Draft {
+	case config.DraftSkip:
+		prc.steps = append(prc.steps, Step{status: Skip, message: DraftExitMsg, id: DraftStepID, exit: true})
+	case config.DraftWarn:
+		prc.steps = append(prc.steps, Step{status: Warn, message: DraftWarnMsg, id: DraftStepID})
+		prc.warnOnly = true
+	default:
+		prc.steps = append(prc.steps, Step{status: Err, message: DraftErrMsg, id: DraftStepID})
+		prc.errors++
+	}
+
+	return prc
+}
@@ -0,0 +1,96 @@
+package plugin
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+func TestPullRequestChecker_CheckPRDraft(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings config.Settings
+		draft    bool
+		want     []Step
+		errors   int
+		warnOnly bool
+	}{
+		{
+			name:     "CheckPRDraftRun",
+			settings: config.Settings{Draft: config.DraftRun},
+			draft:    true,
+			want:     []Step{{status: Skip, message: DraftSkipMsg, id: DraftStepID}},
+		},
+		{
+			name:     "CheckPRDraftNotDraft",
+			settings: config.Settings{Draft: config.DraftFail, Title: "feat: wipe caches"},
+			want:     []Step{{status: Success, message: DraftSuccesMsg, id: DraftStepID}},
+		},
+		{
+			name:     "CheckPRDraftSkip",
+			settings: config.Settings{Draft: config.DraftSkip},
+			draft:    true,
+			want:     []Step{{status: Skip, message: DraftExitMsg, id: DraftStepID, exit: true}},
+		},
+		{
+			name:     "CheckPRDraftFailWIPTitle",
+			settings: config.Settings{Draft: config.DraftFail, Title: "[WIP] feat: add a new feature"},
+			want:     []Step{{status: Err, message: DraftErrMsg, id: DraftStepID}},
+			errors:   1,
+		},
+		{
+			name:     "CheckPRDraftWarnDraftTitle",
+			settings: config.Settings{Draft: config.DraftWarn, Title: "Draft: feat: add a new feature"},
+			want:     []Step{{status: Warn, message: DraftWarnMsg, id: DraftStepID}},
+			warnOnly: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.settings,
+				github:   &TestGithubClient{draft: github.Bool(tt.draft)},
+			}
+			got := prc.checkPRDraft()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors || got.warnOnly != tt.warnOnly {
+				t.Errorf("PullRequestChecker.CheckPRDraft() = %v (%d errors, warn only %v), want %v (%d errors, warn only %v)",
+					got.steps, got.errors, got.warnOnly, tt.want, tt.errors, tt.warnOnly)
+			}
+		})
+	}
+}
+
+func TestPullRequestChecker_RunDraft(t *testing.T) {
+	t.Run("RunDraftWarnDowngradesErrors", func(t *testing.T) {
+		prc := &PullRequestChecker{
+			settings: config.Settings{Draft: config.DraftWarn, Prefixes: []string{"feat:"}, Title: "chore: tidy up"},
+			github:   &TestGithubClient{draft: github.Bool(true)},
+		}
+		prc.run()
+
+		want := Step{status: Warn, message: fmt.Sprintf(PrefixErrMsg, "feat:"), id: PrefixStepID}
+		if prc.errors != 0 || !slices.Contains(prc.steps, want) {
+			t.Errorf("PullRequestChecker.run() = %v (%d errors), want %v and no errors", prc.steps, prc.errors, want)
+		}
+	})
+
+	t.Run("RunDraftSkipStopsEarly", func(t *testing.T) {
+		prc := &PullRequestChecker{
+			settings: config.Settings{Draft: config.DraftSkip, Prefixes: []string{"feat:"}, Title: "chore: tidy up"},
+			github:   &TestGithubClient{draft: github.Bool(true)},
+		}
+		prc.run()
+
+		want := []Step{
+			{status: Skip, message: LabelsSkipMsg, id: LabelsStepID},
+			{status: Skip, message: DraftExitMsg, id: DraftStepID, exit: true},
+		}
+		if !reflect.DeepEqual(prc.steps, want) || prc.errors != 0 {
+			t.Errorf("PullRequestChecker.run() = %v (%d errors), want %v", prc.steps, prc.errors, want)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package plugin
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/glob"
+)
+
+func (prc *PullRequestChecker) checkPRFreeze() *PullRequestChecker {
+
+	if len(prc.settings.FreezeWindows) == 0 {
+		prc.steps = append(prc.steps, Step{status: Skip, message: FreezeSkipMsg, id: FreezeStepID})
+		return prc
+	}
+
+	// Without protected branches configured every target branch is frozen.
+	if len(prc.settings.FreezeBranches) > 0 && !glob.MatchAny(prc.settings.FreezeBranches, prc.settings.TargetBranch) {
+		prc.steps = append(prc.steps, Step{status: Success, message: FreezeSuccesMsg, id: FreezeStepID})
+		return prc
+	}
+
+	// Every window is checked against the same instant.
+	now := prc.now()
+
+	active := []string{}
+	for _, window := range prc.settings.FreezeWindows {
+		if window.Contains(now) {
+			active = append(active, window.Spec)
+		}
+	}
+
+	if len(active) == 0 {
+		prc.steps = append(prc.steps, Step{status: Success, message: FreezeSuccesMsg, id: FreezeStepID})
+		return prc
+	}
+
+	if prc.settings.FreezeOverride != "" {
+		pr, err := prc.github.GetPullRequest(prc.settings.Owner, prc.settings.Repo, prc.settings.PullRequest)
+		if err != nil {
+			return prc.githubError(FreezeStepID, err)
+		}
+
+		labels := []string{}
+		for _, label := range pr.Labels {
+			labels = append(labels, label.GetName())
+		}
+
+		if slices.Contains(labels, prc.settings.FreezeOverride) {
+			prc.steps = append(
+				prc.steps,
+				Step{
+					status:  Success,
+					message: fmt.Sprintf(FreezeOverrideMsg, strings.Join(active, ", "), prc.settings.FreezeOverride),
+					id:      FreezeStepID,
+				},
+			)
+			return prc
+		}
+	}
+
+	return prc.fail(
+		FreezeStepID,
+		fmt.Sprintf(FreezeErrMsg, prc.settings.TargetBranch, strings.Join(active, ", ")),
+		prc.settings.FreezeSeverity,
+	)
+}
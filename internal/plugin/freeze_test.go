@@ -0,0 +1,126 @@
+package plugin
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+func TestPullRequestChecker_CheckPRFreeze(t *testing.T) {
+	friday, err := config.ParseFreezeWindow("Fri 15:00-24:00", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	holidays, err := config.ParseFreezeWindow("2026-12-20/2027-01-02", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	windows := []config.FreezeWindow{friday, holidays}
+
+	release, err := config.ParseFreezeWindow("Fri 16:00-17:00", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fridayEvening := func() time.Time { return time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC) }
+	mondayMorning := func() time.Time { return time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC) }
+	// ticking returns Friday evening once and Monday morning afterwards.
+	ticking := func() func() time.Time {
+		calls := 0
+		return func() time.Time {
+			calls++
+			if calls == 1 {
+				return fridayEvening()
+			}
+			return mondayMorning()
+		}
+	}
+
+	tests := []struct {
+		name     string
+		settings config.Settings
+		now      func() time.Time
+		labels   []*github.Label
+		want     []Step
+		errors   int
+	}{
+		{
+			name:     "CheckPRFreezeNoWindows",
+			settings: config.Settings{},
+			now:      fridayEvening,
+			want:     []Step{{status: Skip, message: FreezeSkipMsg, id: FreezeStepID}},
+		},
+		{
+			name:     "CheckPRFreezeOutsideWindow",
+			settings: config.Settings{FreezeWindows: windows, TargetBranch: "main"},
+			now:      mondayMorning,
+			want:     []Step{{status: Success, message: FreezeSuccesMsg, id: FreezeStepID}},
+		},
+		{
+			name:     "CheckPRFreezeUnprotectedBranch",
+			settings: config.Settings{FreezeWindows: windows, FreezeBranches: []string{"main", "release/*"}, TargetBranch: "develop"},
+			now:      fridayEvening,
+			want:     []Step{{status: Success, message: FreezeSuccesMsg, id: FreezeStepID}},
+		},
+		{
+			name:     "CheckPRFreezeFrozen",
+			settings: config.Settings{FreezeWindows: windows, FreezeBranches: []string{"main", "release/*"}, TargetBranch: "release/1.2"},
+			now:      fridayEvening,
+			want:     []Step{{status: Err, message: fmt.Sprintf(FreezeErrMsg, "release/1.2", "Fri 15:00-24:00"), id: FreezeStepID}},
+			errors:   1,
+		},
+		{
+			name: "CheckPRFreezeFrozenWarning",
+			settings: config.Settings{
+				FreezeWindows:  windows,
+				FreezeSeverity: config.SeverityWarning,
+				TargetBranch:   "main",
+			},
+			now:  fridayEvening,
+			want: []Step{{status: Warn, message: fmt.Sprintf(FreezeErrMsg, "main", "Fri 15:00-24:00"), id: FreezeStepID}},
+		},
+		{
+			name: "CheckPRFreezeOverridden",
+			settings: config.Settings{
+				FreezeWindows:  windows,
+				FreezeOverride: "freeze-override",
+				TargetBranch:   "main",
+			},
+			now:    fridayEvening,
+			labels: []*github.Label{{Name: github.String("freeze-override")}},
+			want: []Step{{
+				status:  Success,
+				message: fmt.Sprintf(FreezeOverrideMsg, "Fri 15:00-24:00", "freeze-override"),
+				id:      FreezeStepID,
+			}},
+		},
+		{
+			name:     "CheckPRFreezeSingleInstant",
+			settings: config.Settings{FreezeWindows: []config.FreezeWindow{friday, release}, TargetBranch: "main"},
+			now:      ticking(),
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(FreezeErrMsg, "main", "Fri 15:00-24:00, Fri 16:00-17:00"),
+				id:      FreezeStepID,
+			}},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.settings,
+				github:   &TestGithubClient{labels: tt.labels},
+				now:      tt.now,
+			}
+			got := prc.checkPRFreeze()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors {
+				t.Errorf("PullRequestChecker.CheckPRFreeze() = %v (%d errors), want %v (%d errors)", got.steps, got.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package plugin
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/github"
+)
+
+// githubIssueRe matches "#123", including "Fixes #123", but not anchors
+// such as "foo#123" or HTML entities such as "&#123;".
+var githubIssueRe = regexp.MustCompile(`(?:^|[^\w&/])#(\d+)\b`)
+
+// issueKeyRegexp returns the expression matching tracker keys for the
+// configured project keys. Without project keys, tokens such as "UTF-8" or
+// "SHA-256" cannot be told apart from tracker keys, so none are matched.
+func issueKeyRegexp(keys []string) *regexp.Regexp {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	quoted := []string{}
+	for _, key := range keys {
+		quoted = append(quoted, regexp.QuoteMeta(key))
+	}
+	return regexp.MustCompile(fmt.Sprintf(`\b(?:%s)-\d+\b`, strings.Join(quoted, "|")))
+}
+
+func (prc *PullRequestChecker) checkPRIssueReference() *PullRequestChecker {
+
+	if !prc.settings.IssueReference {
+		prc.steps = append(prc.steps, Step{status: Skip, message: IssueSkipMsg, id: IssueStepID})
+		return prc
+	}
+
+	pr, err := prc.github.GetPullRequest(
+		prc.settings.Owner,
+		prc.settings.Repo,
+		prc.settings.PullRequest,
+	)
+
+	if err != nil {
+		return prc.githubError(IssueStepID, err)
+	}
+
+	text := strings.Join([]string{prc.settings.Title, pr.GetBody(), prc.settings.SourceBranch}, "\n")
+
+	keys := []string{}
+	if re := issueKeyRegexp(prc.settings.IssueKeys); re != nil {
+		keys = uniq(re.FindAllString(text, -1))
+	}
+	numbers := []string{}
+	for _, match := range githubIssueRe.FindAllStringSubmatch(text, -1) {
+		numbers = append(numbers, match[1])
+	}
+	numbers = uniq(numbers)
+
+	if len(keys) == 0 && len(numbers) == 0 {
+		prc.steps = append(prc.steps, Step{status: Err, message: IssueErrMsg, id: IssueStepID})
+		prc.errors++
+		return prc
+	}
+
+	if !prc.settings.IssueVerify {
+		prc.steps = append(prc.steps, Step{status: Success, message: IssueSuccesMsg, id: IssueStepID})
+		return prc
+	}
+
+	invalid := []string{}
+
+	for _, number := range numbers {
+		n, _ := strconv.Atoi(number)
+		issue, err := prc.github.GetIssue(prc.settings.Owner, prc.settings.Repo, n)
+		switch {
+		case github.IsNotFound(err):
+			invalid = append(invalid, fmt.Sprintf("#%s does not exist", number))
+		case err != nil:
+			return prc.githubError(IssueStepID, err)
+		case issue.GetState() != "open":
+			invalid = append(invalid, fmt.Sprintf("#%s is %s", number, issue.GetState()))
+		default:
+			prc.steps = append(prc.steps, Step{status: Success, message: IssueSuccesMsg, id: IssueStepID})
+			return prc
+		}
+	}
+
+	for _, key := range keys {
+		if prc.tracker == nil {
+			invalid = append(invalid, fmt.Sprintf("%s could not be verified (no issue tracker configured)", key))
+			continue
+		}
+		exists, err := prc.tracker.IssueExists(key)
+		switch {
+		case err != nil:
+			invalid = append(invalid, fmt.Sprintf("%s could not be verified (%s)", key, err))
+		case !exists:
+			invalid = append(invalid, fmt.Sprintf("%s does not exist", key))
+		default:
+			prc.steps = append(prc.steps, Step{status: Success, message: IssueSuccesMsg, id: IssueStepID})
+			return prc
+		}
+	}
+
+	prc.steps = append(
+		prc.steps,
+		Step{
+			status:  Err,
+			message: fmt.Sprintf(IssueInvalid, strings.Join(invalid, ", ")),
+			id:      IssueStepID,
+		},
+	)
+	prc.errors++
+	return prc
+}
+
+func uniq(values []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,161 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+	g "github.com/nyambati/drone-pr-checker/internal/github"
+	"github.com/nyambati/drone-pr-checker/internal/tracker"
+)
+
+type TestTracker struct {
+	issues map[string]bool
+	err    error
+}
+
+func (t *TestTracker) IssueExists(key string) (bool, error) {
+	return t.issues[key], t.err
+}
+
+func TestPullRequestChecker_CheckPRIssueReference(t *testing.T) {
+	openIssues := map[int]*github.Issue{
+		12: {State: github.String("open")},
+		13: {State: github.String("closed")},
+	}
+
+	type fields struct {
+		settings config.Settings
+		github   g.GitHubInterface
+		tracker  tracker.TrackerInterface
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   []Step
+		errors int
+	}{
+		{
+			name:   "CheckPRIssueReferenceDisabled",
+			fields: fields{settings: config.Settings{}, github: &TestGithubClient{}},
+			want:   []Step{{status: Skip, message: IssueSkipMsg, id: IssueStepID}},
+		},
+		{
+			name: "CheckPRIssueReferenceMissing",
+			fields: fields{
+				settings: config.Settings{IssueReference: true, Title: "feat: add a new feature", SourceBranch: "feature"},
+				github:   &TestGithubClient{body: github.String("utf-8 everywhere, see foo#12")},
+			},
+			want:   []Step{{status: Err, message: IssueErrMsg, id: IssueStepID}},
+			errors: 1,
+		},
+		{
+			name: "CheckPRIssueReferenceKeyInBranch",
+			fields: fields{
+				settings: config.Settings{IssueReference: true, IssueKeys: []string{"ABC", "OPS"}, SourceBranch: "feat/OPS-42-thing"},
+				github:   &TestGithubClient{},
+			},
+			want: []Step{{status: Success, message: IssueSuccesMsg, id: IssueStepID}},
+		},
+		{
+			name: "CheckPRIssueReferenceUnknownProjectKey",
+			fields: fields{
+				settings: config.Settings{IssueReference: true, IssueKeys: []string{"ABC"}, Title: "XYZ-1 fix things"},
+				github:   &TestGithubClient{},
+			},
+			want:   []Step{{status: Err, message: IssueErrMsg, id: IssueStepID}},
+			errors: 1,
+		},
+		{
+			name: "CheckPRIssueReferenceTechnicalTokens",
+			fields: fields{
+				settings: config.Settings{IssueReference: true, Title: "fix: UTF-8 decoding", SourceBranch: "fix/sha-256"},
+				github:   &TestGithubClient{body: github.String("Fixes UTF-8 decoding.\nBump to SHA-256, dates in ISO-8601.")},
+			},
+			want:   []Step{{status: Err, message: IssueErrMsg, id: IssueStepID}},
+			errors: 1,
+		},
+		{
+			name: "CheckPRIssueReferenceTrackerKeyWithoutIssueKeys",
+			fields: fields{
+				settings: config.Settings{IssueReference: true, Title: "ABC-1: fix things"},
+				github:   &TestGithubClient{},
+			},
+			want:   []Step{{status: Err, message: IssueErrMsg, id: IssueStepID}},
+			errors: 1,
+		},
+		{
+			name: "CheckPRIssueReferenceVerifiedGitHubIssue",
+			fields: fields{
+				settings: config.Settings{IssueReference: true, IssueVerify: true},
+				github:   &TestGithubClient{body: github.String("Fixes #12"), issues: openIssues},
+			},
+			want: []Step{{status: Success, message: IssueSuccesMsg, id: IssueStepID}},
+		},
+		{
+			name: "CheckPRIssueReferenceClosedAndMissingGitHubIssues",
+			fields: fields{
+				settings: config.Settings{IssueReference: true, IssueVerify: true},
+				github:   &TestGithubClient{body: github.String("Fixes #13, #99"), issues: openIssues},
+			},
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(IssueInvalid, "#13 is closed, #99 does not exist"),
+				id:      IssueStepID,
+			}},
+			errors: 1,
+		},
+		{
+			name: "CheckPRIssueReferenceVerifiedTrackerKey",
+			fields: fields{
+				settings: config.Settings{IssueReference: true, IssueVerify: true, IssueKeys: []string{"ABC"}, Title: "ABC-1: fix things"},
+				github:   &TestGithubClient{},
+				tracker:  &TestTracker{issues: map[string]bool{"ABC-1": true}},
+			},
+			want: []Step{{status: Success, message: IssueSuccesMsg, id: IssueStepID}},
+		},
+		{
+			name: "CheckPRIssueReferenceVerifyWithoutTracker",
+			fields: fields{
+				settings: config.Settings{IssueReference: true, IssueVerify: true, IssueKeys: []string{"ABC"}, Title: "ABC-1: fix things"},
+				github:   &TestGithubClient{body: github.String("Fixes #13"), issues: openIssues},
+			},
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(IssueInvalid, "#13 is closed, ABC-1 could not be verified (no issue tracker configured)"),
+				id:      IssueStepID,
+			}},
+			errors: 1,
+		},
+		{
+			name: "CheckPRIssueReferenceTrackerError",
+			fields: fields{
+				settings: config.Settings{IssueReference: true, IssueVerify: true, IssueKeys: []string{"ABC"}, Title: "ABC-1: fix things"},
+				github:   &TestGithubClient{},
+				tracker:  &TestTracker{err: errors.New("timeout")},
+			},
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(IssueInvalid, "ABC-1 could not be verified (timeout)"),
+				id:      IssueStepID,
+			}},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.fields.settings,
+				github:   tt.fields.github,
+				tracker:  tt.fields.tracker,
+			}
+			got := prc.checkPRIssueReference()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors {
+				t.Errorf("PullRequestChecker.CheckPRIssueReference() = %v (%d errors), want %v (%d errors)", got.steps, got.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func (prc *PullRequestChecker) checkPRMergeable() *PullRequestChecker {
+
+	if !prc.settings.Mergeable {
+		prc.steps = append(prc.steps, Step{status: Skip, message: MergeableSkipMsg, id: MergeableStepID})
+		return prc
+	}
+
+	pr, err := prc.github.GetPullRequest(prc.settings.Owner, prc.settings.Repo, prc.settings.PullRequest)
+	if err != nil {
+		return prc.githubError(MergeableStepID, err)
+	}
+
+	// GitHub computes mergeability in the background and reports null until
+	// it is done, so poll for a bounded number of attempts.
+	for attempt := 0; pr.Mergeable == nil && attempt < prc.settings.MergeableRetries; attempt++ {
+		time.Sleep(prc.settings.MergeableInterval)
+		pr, err = prc.github.GetPullRequest(prc.settings.Owner, prc.settings.Repo, prc.settings.PullRequest)
+		if err != nil {
+			return prc.githubError(MergeableStepID, err)
+		}
+	}
+
+	if pr.Mergeable == nil {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(MergeableUnknownMsg, prc.settings.MergeableRetries+1),
+				id:      MergeableStepID,
+			},
+		)
+		prc.errors++
+		return prc
+	}
+
+	problems := []string{}
+
+	if !pr.GetMergeable() || pr.GetMergeableState() == "dirty" {
+		problems = append(problems, "it has conflicts with "+pr.GetBase().GetRef())
+	}
+
+	if prc.settings.MaxBehind >= 0 {
+		// Commits on the target branch since the PR base are the commits the
+		// PR has not been tested against.
+		comparison, err := prc.github.CompareCommits(
+			prc.settings.Owner,
+			prc.settings.Repo,
+			pr.GetBase().GetSHA(),
+			pr.GetBase().GetRef(),
+		)
+		if err != nil {
+			return prc.githubError(MergeableStepID, err)
+		}
+		if behind := comparison.GetAheadBy(); behind > prc.settings.MaxBehind {
+			problems = append(
+				problems,
+				fmt.Sprintf("it is %d commits behind %s (max %d)", behind, pr.GetBase().GetRef(), prc.settings.MaxBehind),
+			)
+		}
+	}
+
+	if len(problems) > 0 {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(MergeableErrMsg, strings.Join(problems, ", ")),
+				id:      MergeableStepID,
+			},
+		)
+		prc.errors++
+		return prc
+	}
+
+	prc.steps = append(prc.steps, Step{status: Success, message: MergeableSuccesMsg, id: MergeableStepID})
+	return prc
+}
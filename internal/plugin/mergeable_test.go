@@ -0,0 +1,65 @@
+package plugin
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+func TestPullRequestChecker_CheckPRMergeable(t *testing.T) {
+	base := &github.PullRequestBranch{Ref: github.String("main"), SHA: github.String("base")}
+
+	tests := []struct {
+		name     string
+		settings config.Settings
+		client   *TestGithubClient
+		want     []Step
+		errors   int
+	}{
+		{
+			name:     "CheckPRMergeableDisabled",
+			settings: config.Settings{},
+			client:   &TestGithubClient{},
+			want:     []Step{{status: Skip, message: MergeableSkipMsg, id: MergeableStepID}},
+		},
+		{
+			name:     "CheckPRMergeableAfterPolling",
+			settings: config.Settings{Mergeable: true, MaxBehind: 5, MergeableRetries: 3},
+			client:   &TestGithubClient{base: base, mergeable: []*bool{nil, nil, github.Bool(true)}, aheadBy: 5},
+			want:     []Step{{status: Success, message: MergeableSuccesMsg, id: MergeableStepID}},
+		},
+		{
+			name:     "CheckPRMergeableNeverComputed",
+			settings: config.Settings{Mergeable: true, MaxBehind: -1, MergeableRetries: 2},
+			client:   &TestGithubClient{base: base, mergeable: []*bool{nil}},
+			want:     []Step{{status: Err, message: fmt.Sprintf(MergeableUnknownMsg, 3), id: MergeableStepID}},
+			errors:   1,
+		},
+		{
+			name:     "CheckPRMergeableConflictsAndBehind",
+			settings: config.Settings{Mergeable: true, MaxBehind: 0, MergeableRetries: 1},
+			client:   &TestGithubClient{base: base, mergeable: []*bool{github.Bool(false)}, aheadBy: 3},
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(MergeableErrMsg, "it has conflicts with main, it is 3 commits behind main (max 0)"),
+				id:      MergeableStepID,
+			}},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.settings,
+				github:   tt.client,
+			}
+			got := prc.checkPRMergeable()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors {
+				t.Errorf("PullRequestChecker.CheckPRMergeable() = %v (%d errors), want %v (%d errors)", got.steps, got.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
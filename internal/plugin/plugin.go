@@ -2,15 +2,17 @@ package plugin
 
 import (
 	"fmt"
-	"log"
+	"io"
 	"log/slog"
-	"os"
+	"path"
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/nyambati/drone-pr-checker/internal/config"
 	"github.com/nyambati/drone-pr-checker/internal/github"
+	"github.com/nyambati/drone-pr-checker/internal/tracker"
 )
 
 type PullRequestChecker struct {
@@ -18,17 +20,46 @@ type PullRequestChecker struct {
 	errors   int
 	settings config.Settings
 	github   github.GitHubInterface
+	tracker  tracker.TrackerInterface
+	// skip holds the ids of checks disabled by path rules.
+	skip map[string]bool
+	// warnOnly downgrades every error to a warning, see checkPRDraft.
+	warnOnly bool
+	// now is the time source for the freeze check.
+	now func() time.Time
+}
+
+// fail records a failed step as an error, or only as a warning when the
+// severity asks for it.
+func (prc *PullRequestChecker) fail(id string, message string, severity string) *PullRequestChecker {
+	if severity == config.SeverityWarning {
+		prc.steps = append(prc.steps, Step{status: Warn, message: message, id: id})
+		return prc
+	}
+	prc.steps = append(prc.steps, Step{status: Err, message: message, id: id})
+	prc.errors++
+	return prc
+}
+
+// githubError records a failed GitHub API call for the given step, either as
+// a skip or as an error depending on the IgnoreGitHubError setting.
+func (prc *PullRequestChecker) githubError(id string, err error) *PullRequestChecker {
+	if prc.settings.IgnoreGitHubError {
+		prc.steps = append(prc.steps, Step{status: Skip, message: err.Error(), id: id})
+		return prc
+	}
+	prc.steps = append(prc.steps, Step{status: Err, message: err.Error(), id: id})
+	prc.errors++
+	return prc
 }
 
 func (prc *PullRequestChecker) checkPRTitlePrefixes() *PullRequestChecker {
-	if prc.settings.Prefixes == "" {
+	if len(prc.settings.Prefixes) == 0 {
 		prc.steps = append(prc.steps, Step{status: Skip, message: PrefixSkipMsg, id: PrefixStepID})
 		return prc
 	}
 
-	prefixes := strings.Split(prc.settings.Prefixes, ",")
-
-	for _, prefix := range prefixes {
+	for _, prefix := range prc.settings.Prefixes {
 		if strings.HasPrefix(strings.ToLower(prc.settings.Title), strings.ToLower(prefix)) {
 			prc.steps = append(prc.steps, Step{status: Success, message: PrefixSuccesMsg, id: PrefixStepID})
 			return prc
@@ -40,7 +71,7 @@ func (prc *PullRequestChecker) checkPRTitlePrefixes() *PullRequestChecker {
 		Step{
 			status:  Err,
 			id:      PrefixStepID,
-			message: fmt.Sprintf(PrefixErrMsg, prc.settings.Prefixes),
+			message: fmt.Sprintf(PrefixErrMsg, strings.Join(prc.settings.Prefixes, ", ")),
 		},
 	)
 	prc.errors++
@@ -55,7 +86,12 @@ func (prc *PullRequestChecker) checkPRTitleRegexep() *PullRequestChecker {
 	}
 
 	// run regex against pull request title
-	regex := regexp.MustCompile(prc.settings.Regexp)
+	regex, err := regexp.Compile(prc.settings.Regexp)
+	if err != nil {
+		prc.steps = append(prc.steps, Step{status: Err, message: fmt.Sprintf(PatternErrMsg, err), id: RegexpStepID})
+		prc.errors++
+		return prc
+	}
 
 	if !regex.MatchString(prc.settings.Title) {
 		prc.steps = append(prc.steps, Step{status: Err, message: RegexpErrMsg, id: RegexpStepID})
@@ -69,13 +105,11 @@ func (prc *PullRequestChecker) checkPRTitleRegexep() *PullRequestChecker {
 
 func (prc *PullRequestChecker) checkPRLabels() *PullRequestChecker {
 
-	if prc.settings.SkipOnLabels == "" {
+	if len(prc.settings.SkipOnLabels) == 0 {
 		prc.steps = append(prc.steps, Step{status: Skip, message: LabelsSkipMsg, id: LabelsStepID})
 		return prc
 	}
 
-	labelsToIgnore := strings.Split(prc.settings.SkipOnLabels, ",")
-
 	pr, err := prc.github.GetPullRequest(
 		prc.settings.Owner,
 		prc.settings.Repo,
@@ -83,15 +117,7 @@ func (prc *PullRequestChecker) checkPRLabels() *PullRequestChecker {
 	)
 
 	if err != nil {
-		switch prc.settings.IgnoreGitHubError {
-		case true:
-			prc.steps = append(prc.steps, Step{status: Skip, message: err.Error(), id: LabelsStepID})
-			return prc
-		default:
-			prc.steps = append(prc.steps, Step{status: Err, message: err.Error(), id: LabelsStepID})
-			prc.errors++
-			return prc
-		}
+		return prc.githubError(LabelsStepID, err)
 	}
 
 	labels := []string{}
@@ -100,7 +126,7 @@ func (prc *PullRequestChecker) checkPRLabels() *PullRequestChecker {
 		labels = append(labels, label.GetName())
 	}
 
-	for _, label := range labelsToIgnore {
+	for _, label := range prc.settings.SkipOnLabels {
 		if slices.Contains(labels, label) {
 			prc.steps = append(
 				prc.steps,
@@ -119,96 +145,312 @@ func (prc *PullRequestChecker) checkPRLabels() *PullRequestChecker {
 	return prc
 }
 
-func (prc *PullRequestChecker) checkPRChecklist() *PullRequestChecker {
+func (prc *PullRequestChecker) checkPRCommitSignatures() *PullRequestChecker {
 
-	if !prc.settings.Checklist {
-		prc.steps = append(prc.steps, Step{status: Skip, message: ChecklistSkipMsg, id: ChecklistStepID})
+	if !prc.settings.SignedCommits {
+		prc.steps = append(prc.steps, Step{status: Skip, message: SignaturesSkipMsg, id: SignaturesStepID})
 		return prc
 	}
 
-	pr, err := prc.github.GetPullRequest(
+	commits, err := prc.github.ListCommits(
 		prc.settings.Owner,
 		prc.settings.Repo,
 		prc.settings.PullRequest,
 	)
 
 	if err != nil {
-		switch prc.settings.IgnoreGitHubError {
-		case true:
-			prc.steps = append(prc.steps, Step{status: Skip, message: err.Error(), id: ChecklistStepID})
-			return prc
-		default:
-			prc.steps = append(prc.steps, Step{status: Err, message: err.Error(), id: ChecklistStepID})
-			prc.errors++
-			return prc
+		return prc.githubError(SignaturesStepID, err)
+	}
+
+	unverified := []string{}
+
+	for _, commit := range commits {
+		verification := commit.GetCommit().GetVerification()
+		if verification.GetVerified() {
+			continue
+		}
+		reason := verification.GetReason()
+		if reason == "" {
+			reason = "unsigned"
 		}
+		unverified = append(unverified, fmt.Sprintf("%s (%s)", shortSHA(commit.GetSHA()), reason))
 	}
 
-	re := regexp.MustCompile(
-		fmt.Sprintf(
-			`(?s)%s.*?((?:(?:- \[[ x]\] .+?)(?:\n|$))+)`,
-			prc.settings.ChecklistTitle,
-		),
+	if len(unverified) > 0 {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(SignaturesErrMsg, len(unverified), strings.Join(unverified, ", ")),
+				id:      SignaturesStepID,
+			},
+		)
+		prc.errors++
+		return prc
+	}
+
+	prc.steps = append(prc.steps, Step{status: Success, message: SignaturesSuccesMsg, id: SignaturesStepID})
+	return prc
+}
+
+// autosquashRe matches commit subjects that are meant to be squashed away
+// before merging.
+var autosquashRe = regexp.MustCompile(`(?i)^(fixup!|squash!|\[wip\]|wip\b)`)
+
+func (prc *PullRequestChecker) checkPRCommitHygiene() *PullRequestChecker {
+
+	if !prc.settings.CommitHygiene && prc.settings.MaxCommits <= 0 {
+		prc.steps = append(prc.steps, Step{status: Skip, message: CommitsSkipMsg, id: CommitsStepID})
+		return prc
+	}
+
+	commits, err := prc.github.ListCommits(
+		prc.settings.Owner,
+		prc.settings.Repo,
+		prc.settings.PullRequest,
 	)
 
-	// Find the checklist section
-	checklistSection := re.FindStringSubmatch(pr.GetBody())
+	if err != nil {
+		return prc.githubError(CommitsStepID, err)
+	}
+
+	issues := []string{}
+
+	if prc.settings.MaxCommits > 0 && len(commits) > prc.settings.MaxCommits {
+		issues = append(issues, fmt.Sprintf("%d commits exceeds the maximum of %d", len(commits), prc.settings.MaxCommits))
+	}
+
+	if prc.settings.CommitHygiene {
+		for _, commit := range commits {
+			subject, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+			switch {
+			case len(commit.Parents) > 1:
+				issues = append(issues, fmt.Sprintf("%s is a merge commit", shortSHA(commit.GetSHA())))
+			case autosquashRe.MatchString(subject):
+				issues = append(issues, fmt.Sprintf("%s %q should be squashed", shortSHA(commit.GetSHA()), subject))
+			}
+		}
+	}
+
+	if len(issues) > 0 {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(CommitsErrMsg, len(issues), strings.Join(issues, ", ")),
+				id:      CommitsStepID,
+			},
+		)
+		prc.errors++
+		return prc
+	}
+
+	prc.steps = append(prc.steps, Step{status: Success, message: CommitsSuccesMsg, id: CommitsStepID})
+	return prc
+}
+
+func (prc *PullRequestChecker) checkPRBranchName() *PullRequestChecker {
+
+	if prc.settings.BranchRegexp == "" {
+		prc.steps = append(prc.steps, Step{status: Skip, message: BranchSkipMsg, id: BranchStepID})
+		return prc
+	}
+
+	regex, err := regexp.Compile(prc.settings.BranchRegexp)
+	if err != nil {
+		prc.steps = append(prc.steps, Step{status: Err, message: fmt.Sprintf(PatternErrMsg, err), id: BranchStepID})
+		prc.errors++
+		return prc
+	}
+
+	if !regex.MatchString(prc.settings.SourceBranch) {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(BranchErrMsg, prc.settings.SourceBranch),
+				id:      BranchStepID,
+			},
+		)
+		prc.errors++
+		return prc
+	}
 
-	if len(checklistSection) > 1 {
-		// Extract matched items
-		checklistItemsRe := regexp.MustCompile(`- \[[ ]\] (.+)`)
-		checklistItems := checklistItemsRe.FindAllStringSubmatch(checklistSection[1], -1)
-		if len(checklistItems) > 1 {
+	prc.steps = append(prc.steps, Step{status: Success, message: BranchSuccesMsg, id: BranchStepID})
+	return prc
+}
+
+// checkPRTargetBranch enforces BranchTargets rules of the form
+// "target=source", where both sides are path globs. When at least one rule
+// matches the target branch, the source branch must match one of the sources
+// listed for it. Target branches without rules accept any source branch.
+func (prc *PullRequestChecker) checkPRTargetBranch() *PullRequestChecker {
+
+	if len(prc.settings.BranchTargets) == 0 {
+		prc.steps = append(prc.steps, Step{status: Skip, message: TargetSkipMsg, id: TargetStepID})
+		return prc
+	}
+
+	allowed := []string{}
+
+	for _, rule := range prc.settings.BranchTargets {
+		target, source, found := strings.Cut(rule, "=")
+		if !found {
 			prc.steps = append(
 				prc.steps,
-				Step{
-					status:  Err,
-					message: fmt.Sprintf(ChecklistErrMsg, len(checklistItems)),
-					id:      ChecklistStepID,
-				},
+				Step{status: Err, message: fmt.Sprintf("invalid branch target rule %q", rule), id: TargetStepID},
 			)
 			prc.errors++
 			return prc
 		}
+		if matched, _ := path.Match(target, prc.settings.TargetBranch); !matched {
+			continue
+		}
+		if matched, _ := path.Match(source, prc.settings.SourceBranch); matched {
+			prc.steps = append(prc.steps, Step{status: Success, message: TargetSuccesMsg, id: TargetStepID})
+			return prc
+		}
+		allowed = append(allowed, source)
+	}
 
+	if len(allowed) > 0 {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(TargetErrMsg, prc.settings.SourceBranch, prc.settings.TargetBranch, strings.Join(allowed, ", ")),
+				id:      TargetStepID,
+			},
+		)
+		prc.errors++
+		return prc
 	}
 
-	prc.steps = append(prc.steps, Step{status: Success, message: ChecklistSuccesMsg, id: ChecklistStepID})
+	prc.steps = append(prc.steps, Step{status: Success, message: TargetSuccesMsg, id: TargetStepID})
 	return prc
 }
 
-func (prc *PullRequestChecker) Report() {
-	checker := prc.checkPRLabels().
-		checkPRTitlePrefixes().
-		checkPRTitleRegexep().
-		checkPRChecklist()
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// checks returns the checks in the order they run, keyed by the step id path
+// rules use to skip them.
+func (prc *PullRequestChecker) checks() []check {
+	return []check{
+		{id: PrefixStepID, run: prc.checkPRTitlePrefixes},
+		{id: RegexpStepID, run: prc.checkPRTitleRegexep},
+		{id: TypeStepID, run: prc.checkPRType},
+		{id: BranchStepID, run: prc.checkPRBranchName},
+		{id: TargetStepID, run: prc.checkPRTargetBranch},
+		{id: IssueStepID, run: prc.checkPRIssueReference},
+		{id: RequiredLabelsStepID, run: prc.checkPRRequiredLabels},
+		{id: SectionsStepID, run: prc.checkPRSections},
+		{id: ChecklistStepID, run: prc.checkPRChecklist},
+		{id: ChangelogStepID, run: prc.checkPRChangelog},
+		{id: FreezeStepID, run: prc.checkPRFreeze},
+		{id: MergeableStepID, run: prc.checkPRMergeable},
+		{id: ReviewsStepID, run: prc.checkPRReviews},
+		{id: CodeownersStepID, run: prc.checkPRCodeowners},
+		{id: SignaturesStepID, run: prc.checkPRCommitSignatures},
+		{id: CommitsStepID, run: prc.checkPRCommitHygiene},
+	}
+}
 
-	for _, step := range checker.steps {
+// run executes every check. The labels, draft and path rules checks go first
+// as they decide whether and how the remaining checks run.
+func (prc *PullRequestChecker) run() *PullRequestChecker {
+	prc.checkPRLabels().
+		checkPRDraft()
+
+	if slices.ContainsFunc(prc.steps, func(step Step) bool { return step.exit }) {
+		return prc
+	}
+
+	prc.checkPRRules()
+
+	for _, check := range prc.checks() {
+		if prc.skip[check.id] {
+			prc.steps = append(prc.steps, Step{status: Skip, message: RulesSkippedMsg, id: check.id})
+			continue
+		}
+		check.run()
+	}
+
+	// Draft pull requests in warning mode report failures without failing.
+	if prc.warnOnly {
+		for i, step := range prc.steps {
+			if step.status == Err {
+				prc.steps[i].status = Warn
+			}
+		}
+		prc.errors = 0
+	}
+
+	return prc
+}
+
+// Report runs every check, writes the result of each step to w and returns
+// an error when any of them failed.
+func (prc *PullRequestChecker) Report(w io.Writer) error {
+	return prc.run().report(w)
+}
+
+// ReportOffline runs only the checks with the given step ids and reports
+// them like Report. It skips the labels, draft and path rules checks, so
+// checks that do not call the GitHub API run without it, as in git hooks.
+func (prc *PullRequestChecker) ReportOffline(w io.Writer, ids ...string) error {
+	for _, check := range prc.checks() {
+		if slices.Contains(ids, check.id) {
+			check.run()
+		}
+	}
+	return prc.report(w)
+}
+
+// report writes the steps to w and returns an error when any of them failed.
+func (prc *PullRequestChecker) report(w io.Writer) error {
+	for _, step := range prc.steps {
 		switch step.status {
 		case Err:
-			fmt.Println("❌", slog.String("step", step.id), slog.String("message", strings.ToLower(step.message)))
+			fmt.Fprintln(w, "❌", slog.String("step", step.id), slog.String("message", strings.ToLower(step.message)))
+		case Warn:
+			fmt.Fprintln(w, "⚠️", slog.String("step", step.id), slog.String("message", strings.ToLower(step.message)))
 		case Success:
-			fmt.Println("✅", slog.String("step", step.id), slog.String("message", strings.ToLower(step.message)))
+			fmt.Fprintln(w, "✅", slog.String("step", step.id), slog.String("message", strings.ToLower(step.message)))
 		case Skip:
-			fmt.Println("🦘", slog.String("step", step.id), slog.String("message", strings.ToLower(step.message)))
-			// Exit gracefully when exit is detected. Comes from the labels check.
+			fmt.Fprintln(w, "🦘", slog.String("step", step.id), slog.String("message", strings.ToLower(step.message)))
+			// Stop gracefully when exit is detected. Comes from the labels
+			// and draft checks.
 			if step.exit {
-				os.Exit(0)
+				return nil
 			}
 		}
 	}
 
-	if condition := prc.errors > 0; condition {
-		log.Fatalf("Found %d errors", prc.errors)
+	// Dry runs report what would fail without failing the build.
+	if prc.settings.DryRun {
+		fmt.Fprintf(w, "Dry run, found %d errors\n", prc.errors)
+		return nil
+	}
+
+	if prc.errors > 0 {
+		return fmt.Errorf("found %d errors", prc.errors)
 	}
 
+	return nil
 }
 
-func New(settings config.Settings, github github.GitHubInterface) PullRequestChecker {
+func New(settings config.Settings, github github.GitHubInterface, tracker tracker.TrackerInterface) PullRequestChecker {
 	return PullRequestChecker{
 		steps:    []Step{},
 		errors:   0,
 		github:   github,
+		tracker:  tracker,
 		settings: settings,
+		now:      time.Now,
 	}
 }
@@ -1,9 +1,15 @@
 package plugin
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/google/go-github/v61/github"
@@ -14,21 +20,97 @@ import (
 var pullRequestTitle = "feat: add a new feature"
 
 type TestGithubClient struct {
-	body   *string
-	labels []*github.Label
-	err    error
+	body  *string
+	head  *github.PullRequestBranch
+	draft *bool
+	base  *github.PullRequestBranch
+	// mergeable is returned one value per GetPullRequest call, the last
+	// value repeats.
+	mergeable []*bool
+	aheadBy   int
+	labels    []*github.Label
+	commits   []*github.RepositoryCommit
+	files     []*github.CommitFile
+	reviews   []*github.PullRequestReview
+	teams     map[string][]string
+	issues    map[int]*github.Issue
+	err       error
 }
 
 func (t *TestGithubClient) GetPullRequest(owner string, repo string, number int) (*github.PullRequest, error) {
 	if t.err != nil {
 		return nil, t.err
 	}
+	var mergeable *bool
+	if len(t.mergeable) > 0 {
+		mergeable = t.mergeable[0]
+		if len(t.mergeable) > 1 {
+			t.mergeable = t.mergeable[1:]
+		}
+	}
 	return &github.PullRequest{
-		Body:   t.body,
-		Labels: t.labels,
+		Base:      t.base,
+		Mergeable: mergeable,
+		Body:      t.body,
+		Head:      t.head,
+		Draft:     t.draft,
+		Labels:    t.labels,
 	}, nil
 }
 
+func (t *TestGithubClient) ListCommits(owner string, repo string, number int) ([]*github.RepositoryCommit, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return t.commits, nil
+}
+
+func (t *TestGithubClient) ListFiles(owner string, repo string, number int) ([]*github.CommitFile, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return t.files, nil
+}
+
+func (t *TestGithubClient) ListReviews(owner string, repo string, number int) ([]*github.PullRequestReview, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return t.reviews, nil
+}
+
+func (t *TestGithubClient) IsTeamMember(org string, team string, user string) (bool, error) {
+	if t.err != nil {
+		return false, t.err
+	}
+	return slices.Contains(t.teams[org+"/"+team], user), nil
+}
+
+func (t *TestGithubClient) CompareCommits(owner string, repo string, base string, head string) (*github.CommitsComparison, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return &github.CommitsComparison{AheadBy: github.Int(t.aheadBy)}, nil
+}
+
+func (t *TestGithubClient) GetIssue(owner string, repo string, number int) (*github.Issue, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	issue, ok := t.issues[number]
+	if !ok {
+		return nil, &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	}
+	return issue, nil
+}
+
+func (t *TestGithubClient) GetContents(owner string, repo string, path string, ref string) ([]byte, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return nil, &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+}
+
 func TestPullRequestChecker_CheckPRTitlePrefixes(t *testing.T) {
 	type fields struct {
 		settings config.Settings
@@ -42,7 +124,7 @@ func TestPullRequestChecker_CheckPRTitlePrefixes(t *testing.T) {
 			name: "CheckPRTitlePrefixesEmptyString",
 			fields: fields{
 				settings: config.Settings{
-					Prefixes: "",
+					Prefixes: []string{},
 					Title:    pullRequestTitle,
 				},
 			},
@@ -58,7 +140,7 @@ func TestPullRequestChecker_CheckPRTitlePrefixes(t *testing.T) {
 			name: "CheckPRTitlePrefixesValidString",
 			fields: fields{
 				settings: config.Settings{
-					Prefixes: "feat:",
+					Prefixes: []string{"feat:"},
 					Title:    pullRequestTitle,
 				},
 			},
@@ -74,7 +156,7 @@ func TestPullRequestChecker_CheckPRTitlePrefixes(t *testing.T) {
 			name: "CheckPRTitlePrefixesInvalidString",
 			fields: fields{
 				settings: config.Settings{
-					Prefixes: "chore:",
+					Prefixes: []string{"chore:"},
 					Title:    pullRequestTitle,
 				},
 			},
@@ -83,7 +165,7 @@ func TestPullRequestChecker_CheckPRTitlePrefixes(t *testing.T) {
 					settings: settings,
 					steps: []Step{{
 						status:  Err,
-						message: fmt.Sprintf(PrefixErrMsg, settings.Prefixes),
+						message: fmt.Sprintf(PrefixErrMsg, "chore:"),
 						id:      PrefixStepID,
 					}},
 					errors: 1,
@@ -161,6 +243,26 @@ func TestPullRequestChecker_CheckPRTitleRegexep(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "CheckPRTitleRegexEpMalformedRegex",
+			fields: fields{
+				settings: config.Settings{
+					Regexp: `^feat(:.*$`,
+					Title:  "feat: add a new feature",
+				},
+			},
+			want: func(settings config.Settings) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					steps: []Step{{
+						status:  Err,
+						message: "Invalid regular expression: error parsing regexp: missing closing ): `^feat(:.*$`",
+						id:      RegexpStepID,
+					}},
+					errors: 1,
+				}
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -202,7 +304,7 @@ func TestPullRequestChecker_CheckPRLabels(t *testing.T) {
 		{
 			name: "CheckPRLabelsMatchLabels",
 			fields: fields{
-				settings: config.Settings{SkipOnLabels: "label1"},
+				settings: config.Settings{SkipOnLabels: []string{"label1"}},
 				github: &TestGithubClient{
 					labels: []*github.Label{
 						{
@@ -223,7 +325,7 @@ func TestPullRequestChecker_CheckPRLabels(t *testing.T) {
 		{
 			name: "CheckPRLabelsNoMatchLabels",
 			fields: fields{
-				settings: config.Settings{SkipOnLabels: "label3,label4"},
+				settings: config.Settings{SkipOnLabels: []string{"label3", "label4"}},
 				github:   &TestGithubClient{},
 			},
 			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
@@ -238,7 +340,7 @@ func TestPullRequestChecker_CheckPRLabels(t *testing.T) {
 		{
 			name: "CheckPRLabelsSkipOnGithubError",
 			fields: fields{
-				settings: config.Settings{SkipOnLabels: "label3,label4"},
+				settings: config.Settings{SkipOnLabels: []string{"label3", "label4"}},
 				github: &TestGithubClient{
 					err: errors.New("Error"),
 				},
@@ -262,7 +364,7 @@ func TestPullRequestChecker_CheckPRLabels(t *testing.T) {
 			name: "CheckPRLabelsInvalidSkipOnGithubError",
 			fields: fields{
 				settings: config.Settings{
-					SkipOnLabels:      "label3,label4",
+					SkipOnLabels:      []string{"label3", "label4"},
 					IgnoreGitHubError: true,
 				},
 				github: &TestGithubClient{
@@ -316,6 +418,46 @@ func TestPullRequestChecker_CheckPRChecklist(t *testing.T) {
 
 		`,
 	)
+	prBodySingleUnchecked := []byte("## Checklist (required)\r\n" +
+		"* [X] Completed code review\r\n" +
+		"+ [x] Wrote tests\r\n" +
+		"  - [ ] Ran unit tests\r\n" +
+		"- [ ] Completed e2e tests\r\n" +
+		"## Notes\r\n" +
+		"- [ ] Not part of the checklist\r\n",
+	)
+
+	template := filepath.Join(t.TempDir(), "pull_request_template.md")
+	err := os.WriteFile(template, []byte(`## Summary
+
+## Checklist
+- [ ] Completed code review
+- [ ] Ran unit tests
+- [ ] Updated the changelog (optional)
+- [ ] Completed e2e tests
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prBodyReworded := []byte(`
+## Checklist
+- [x] Completed code review
+- [x] Ran some tests
+`,
+	)
+
+	prBodyOptional := []byte(`
+## Checklist
+- [x] Completed code review
+- [ ] Updated the changelog (optional)
+- [ ] ~~Ran database migrations~~
+  - [ ] Checked migration rollback
+- [ ] Updated dashboards
+- [ ] Completed e2e tests
+`,
+	)
+
 	type fields struct {
 		settings config.Settings
 		github   g.GitHubInterface
@@ -345,7 +487,7 @@ func TestPullRequestChecker_CheckPRChecklist(t *testing.T) {
 		{
 			name: "CheckPRChecklistUnchecked",
 			fields: fields{
-				settings: config.Settings{Checklist: true},
+				settings: config.Settings{Checklist: true, ChecklistTitle: "## Checklist"},
 				github: &TestGithubClient{
 					body: github.String(string(prBodyUnchecked)),
 				},
@@ -357,7 +499,7 @@ func TestPullRequestChecker_CheckPRChecklist(t *testing.T) {
 					steps: []Step{
 						{
 							status:  Err,
-							message: fmt.Sprintf(ChecklistErrMsg, 3),
+							message: fmt.Sprintf(ChecklistErrMsg, 3, `"Completed code review", "Ran unit tests", "Completed e2e tests"`),
 							id:      ChecklistStepID,
 						},
 					},
@@ -368,7 +510,154 @@ func TestPullRequestChecker_CheckPRChecklist(t *testing.T) {
 		{
 			name: "CheckPRChecklistChecked",
 			fields: fields{
-				settings: config.Settings{Checklist: true},
+				settings: config.Settings{Checklist: true, ChecklistTitle: "## Checklist"},
+				github: &TestGithubClient{
+					body: github.String(string(prBodyChecked)),
+				},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps: []Step{
+						{
+							status:  Success,
+							message: ChecklistSuccesMsg,
+							id:      ChecklistStepID,
+						},
+					},
+					errors: 0,
+				}
+			},
+		},
+		{
+			name: "CheckPRChecklistSingleUnchecked",
+			fields: fields{
+				settings: config.Settings{Checklist: true, ChecklistTitle: "## Checklist (required)"},
+				github: &TestGithubClient{
+					body: github.String(string(prBodySingleUnchecked)),
+				},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps: []Step{
+						{
+							status:  Err,
+							message: fmt.Sprintf(ChecklistErrMsg, 2, `"Ran unit tests", "Completed e2e tests"`),
+							id:      ChecklistStepID,
+						},
+					},
+					errors: 1,
+				}
+			},
+		},
+		{
+			name: "CheckPRChecklistOptionalItems",
+			fields: fields{
+				settings: config.Settings{
+					Checklist:         true,
+					ChecklistTitle:    "## Checklist",
+					ChecklistOptional: []string{"updated dashboards"},
+				},
+				github: &TestGithubClient{
+					body: github.String(string(prBodyOptional)),
+				},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps: []Step{
+						{
+							status:  Err,
+							message: fmt.Sprintf(ChecklistErrMsg, 1, `"Completed e2e tests"`),
+							id:      ChecklistStepID,
+						},
+					},
+					errors: 1,
+				}
+			},
+		},
+		{
+			name: "CheckPRChecklistTemplateSectionDeleted",
+			fields: fields{
+				settings: config.Settings{Checklist: true, ChecklistTitle: "## Checklist", ChecklistTemplate: true, Template: template},
+				github: &TestGithubClient{
+					body: github.String("## Summary\nAll good"),
+				},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps: []Step{
+						{
+							status:  Err,
+							message: fmt.Sprintf(ChecklistMissingMsg, "## Checklist"),
+							id:      ChecklistStepID,
+						},
+					},
+					errors: 1,
+				}
+			},
+		},
+		{
+			name: "CheckPRChecklistTemplateMissing",
+			fields: fields{
+				settings: config.Settings{
+					Checklist:         true,
+					ChecklistTitle:    "## Checklist",
+					ChecklistTemplate: true,
+					Template:          filepath.Join(t.TempDir(), "missing.md"),
+				},
+				github: &TestGithubClient{
+					body: github.String("## Summary\nAll good"),
+				},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps: []Step{
+						{
+							status:  Err,
+							message: fmt.Sprintf(ChecklistTemplateErrMsg, settings.Template),
+							id:      ChecklistStepID,
+						},
+					},
+					errors: 1,
+				}
+			},
+		},
+		{
+			name: "CheckPRChecklistTemplateItemsReworded",
+			fields: fields{
+				settings: config.Settings{Checklist: true, ChecklistTitle: "## Checklist", ChecklistTemplate: true, Template: template},
+				github: &TestGithubClient{
+					body: github.String(string(prBodyReworded)),
+				},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps: []Step{
+						{
+							status:  Err,
+							message: fmt.Sprintf(ChecklistRemovedMsg, 2, `"Ran unit tests", "Completed e2e tests"`),
+							id:      ChecklistStepID,
+						},
+					},
+					errors: 1,
+				}
+			},
+		},
+		{
+			name: "CheckPRChecklistTemplateItemsTicked",
+			fields: fields{
+				settings: config.Settings{Checklist: true, ChecklistTitle: "## Checklist", ChecklistTemplate: true, Template: template},
 				github: &TestGithubClient{
 					body: github.String(string(prBodyChecked)),
 				},
@@ -388,6 +677,29 @@ func TestPullRequestChecker_CheckPRChecklist(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "CheckPRChecklistMissingSection",
+			fields: fields{
+				settings: config.Settings{Checklist: true, ChecklistTitle: "## Checklist"},
+				github: &TestGithubClient{
+					body: github.String("## Summary\n- [ ] not a checklist item"),
+				},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps: []Step{
+						{
+							status:  Success,
+							message: ChecklistSuccesMsg,
+							id:      ChecklistStepID,
+						},
+					},
+					errors: 0,
+				}
+			},
+		},
 		{
 			name: "CheckPRChecklistInvalidSkipOnGithubError",
 			fields: fields{
@@ -447,3 +759,411 @@ func TestPullRequestChecker_CheckPRChecklist(t *testing.T) {
 		})
 	}
 }
+
+func TestPullRequestChecker_CheckPRCommitSignatures(t *testing.T) {
+	verified := &github.RepositoryCommit{
+		SHA: github.String("1a2b3c4d5e6f"),
+		Commit: &github.Commit{
+			Verification: &github.SignatureVerification{Verified: github.Bool(true), Reason: github.String("valid")},
+		},
+	}
+	unsigned := &github.RepositoryCommit{
+		SHA: github.String("abcdef123456"),
+		Commit: &github.Commit{
+			Verification: &github.SignatureVerification{Verified: github.Bool(false), Reason: github.String("unsigned")},
+		},
+	}
+	unknownKey := &github.RepositoryCommit{
+		SHA: github.String("0987654321ab"),
+		Commit: &github.Commit{
+			Verification: &github.SignatureVerification{Verified: github.Bool(false), Reason: github.String("unknown_key")},
+		},
+	}
+
+	type fields struct {
+		settings config.Settings
+		github   g.GitHubInterface
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker
+	}{
+		{
+			name: "CheckPRCommitSignaturesDisabled",
+			fields: fields{
+				settings: config.Settings{},
+				github:   &TestGithubClient{commits: []*github.RepositoryCommit{unsigned}},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps:    []Step{{status: Skip, message: SignaturesSkipMsg, id: SignaturesStepID}},
+					errors:   0,
+				}
+			},
+		},
+		{
+			name: "CheckPRCommitSignaturesVerified",
+			fields: fields{
+				settings: config.Settings{SignedCommits: true},
+				github:   &TestGithubClient{commits: []*github.RepositoryCommit{verified}},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps:    []Step{{status: Success, message: SignaturesSuccesMsg, id: SignaturesStepID}},
+					errors:   0,
+				}
+			},
+		},
+		{
+			name: "CheckPRCommitSignaturesUnverified",
+			fields: fields{
+				settings: config.Settings{SignedCommits: true},
+				github:   &TestGithubClient{commits: []*github.RepositoryCommit{verified, unsigned, unknownKey}},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps: []Step{{
+						status:  Err,
+						message: fmt.Sprintf(SignaturesErrMsg, 2, "abcdef1 (unsigned), 0987654 (unknown_key)"),
+						id:      SignaturesStepID,
+					}},
+					errors: 1,
+				}
+			},
+		},
+		{
+			name: "CheckPRCommitSignaturesGitHubError",
+			fields: fields{
+				settings: config.Settings{SignedCommits: true},
+				github:   &TestGithubClient{err: errors.New("Error")},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps:    []Step{{status: Err, message: "Error", id: SignaturesStepID}},
+					errors:   1,
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.fields.settings,
+				github:   tt.fields.github,
+			}
+			if got := prc.checkPRCommitSignatures(); !reflect.DeepEqual(got, tt.want(tt.fields.settings, tt.fields.github)) {
+				t.Errorf("PullRequestChecker.CheckPRCommitSignatures() = %v, want %v", got, tt.want(tt.fields.settings, tt.fields.github))
+			}
+		})
+	}
+}
+
+func TestPullRequestChecker_CheckPRCommitHygiene(t *testing.T) {
+	commit := func(sha string, message string, parents int) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:     github.String(sha),
+			Commit:  &github.Commit{Message: github.String(message)},
+			Parents: make([]*github.Commit, parents),
+		}
+	}
+	clean := []*github.RepositoryCommit{
+		commit("1111111aaaa", "feat: add a new feature", 1),
+		commit("2222222bbbb", "fix: handle empty body\n\nfixup! is fine in the body", 1),
+	}
+	dirty := []*github.RepositoryCommit{
+		commit("1111111aaaa", "feat: add a new feature", 1),
+		commit("3333333cccc", "Merge branch 'main' into feature", 2),
+		commit("4444444dddd", "fixup! feat: add a new feature", 1),
+		commit("5555555eeee", "WIP", 1),
+	}
+
+	type fields struct {
+		settings config.Settings
+		github   g.GitHubInterface
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker
+	}{
+		{
+			name: "CheckPRCommitHygieneDisabled",
+			fields: fields{
+				settings: config.Settings{},
+				github:   &TestGithubClient{commits: dirty},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps:    []Step{{status: Skip, message: CommitsSkipMsg, id: CommitsStepID}},
+					errors:   0,
+				}
+			},
+		},
+		{
+			name: "CheckPRCommitHygieneClean",
+			fields: fields{
+				settings: config.Settings{CommitHygiene: true, MaxCommits: 2},
+				github:   &TestGithubClient{commits: clean},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps:    []Step{{status: Success, message: CommitsSuccesMsg, id: CommitsStepID}},
+					errors:   0,
+				}
+			},
+		},
+		{
+			name: "CheckPRCommitHygieneDirty",
+			fields: fields{
+				settings: config.Settings{CommitHygiene: true},
+				github:   &TestGithubClient{commits: dirty},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps: []Step{{
+						status: Err,
+						message: fmt.Sprintf(
+							CommitsErrMsg,
+							3,
+							`3333333 is a merge commit, 4444444 "fixup! feat: add a new feature" should be squashed, 5555555 "WIP" should be squashed`,
+						),
+						id: CommitsStepID,
+					}},
+					errors: 1,
+				}
+			},
+		},
+		{
+			name: "CheckPRCommitHygieneMaxCommits",
+			fields: fields{
+				settings: config.Settings{MaxCommits: 1},
+				github:   &TestGithubClient{commits: dirty},
+			},
+			want: func(settings config.Settings, github g.GitHubInterface) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					github:   github,
+					steps: []Step{{
+						status:  Err,
+						message: fmt.Sprintf(CommitsErrMsg, 1, "4 commits exceeds the maximum of 1"),
+						id:      CommitsStepID,
+					}},
+					errors: 1,
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.fields.settings,
+				github:   tt.fields.github,
+			}
+			if got := prc.checkPRCommitHygiene(); !reflect.DeepEqual(got, tt.want(tt.fields.settings, tt.fields.github)) {
+				t.Errorf("PullRequestChecker.CheckPRCommitHygiene() = %v, want %v", got, tt.want(tt.fields.settings, tt.fields.github))
+			}
+		})
+	}
+}
+
+func TestPullRequestChecker_CheckPRBranchName(t *testing.T) {
+	type fields struct {
+		settings config.Settings
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   func(settings config.Settings) *PullRequestChecker
+	}{
+		{
+			name:   "CheckPRBranchNameEmptyString",
+			fields: fields{settings: config.Settings{SourceBranch: "feat/ABC-1-thing"}},
+			want: func(settings config.Settings) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					steps:    []Step{{status: Skip, message: BranchSkipMsg, id: BranchStepID}},
+				}
+			},
+		},
+		{
+			name: "CheckPRBranchNameValid",
+			fields: fields{settings: config.Settings{
+				BranchRegexp: `^(feat|fix)/[A-Z]+-\d+-.+`,
+				SourceBranch: "feat/ABC-1-thing",
+			}},
+			want: func(settings config.Settings) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					steps:    []Step{{status: Success, message: BranchSuccesMsg, id: BranchStepID}},
+				}
+			},
+		},
+		{
+			name: "CheckPRBranchNameInvalid",
+			fields: fields{settings: config.Settings{
+				BranchRegexp: `^(feat|fix)/[A-Z]+-\d+-.+`,
+				SourceBranch: "my-hotfix",
+			}},
+			want: func(settings config.Settings) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					steps:    []Step{{status: Err, message: fmt.Sprintf(BranchErrMsg, "my-hotfix"), id: BranchStepID}},
+					errors:   1,
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.fields.settings,
+			}
+			if got := prc.checkPRBranchName(); !reflect.DeepEqual(got, tt.want(tt.fields.settings)) {
+				t.Errorf("PullRequestChecker.CheckPRBranchName() = %v, want %v", got, tt.want(tt.fields.settings))
+			}
+		})
+	}
+}
+
+func TestPullRequestChecker_CheckPRTargetBranch(t *testing.T) {
+	rules := []string{"main=release/*", "main=hotfix/*"}
+
+	type fields struct {
+		settings config.Settings
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   func(settings config.Settings) *PullRequestChecker
+	}{
+		{
+			name:   "CheckPRTargetBranchEmptyString",
+			fields: fields{settings: config.Settings{SourceBranch: "feat/x", TargetBranch: "main"}},
+			want: func(settings config.Settings) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					steps:    []Step{{status: Skip, message: TargetSkipMsg, id: TargetStepID}},
+				}
+			},
+		},
+		{
+			name:   "CheckPRTargetBranchAllowedSource",
+			fields: fields{settings: config.Settings{BranchTargets: rules, SourceBranch: "hotfix/urgent", TargetBranch: "main"}},
+			want: func(settings config.Settings) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					steps:    []Step{{status: Success, message: TargetSuccesMsg, id: TargetStepID}},
+				}
+			},
+		},
+		{
+			name:   "CheckPRTargetBranchUnrestrictedTarget",
+			fields: fields{settings: config.Settings{BranchTargets: rules, SourceBranch: "feat/x", TargetBranch: "develop"}},
+			want: func(settings config.Settings) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					steps:    []Step{{status: Success, message: TargetSuccesMsg, id: TargetStepID}},
+				}
+			},
+		},
+		{
+			name:   "CheckPRTargetBranchDisallowedSource",
+			fields: fields{settings: config.Settings{BranchTargets: rules, SourceBranch: "feat/x", TargetBranch: "main"}},
+			want: func(settings config.Settings) *PullRequestChecker {
+				return &PullRequestChecker{
+					settings: settings,
+					steps: []Step{{
+						status:  Err,
+						message: fmt.Sprintf(TargetErrMsg, "feat/x", "main", "release/*, hotfix/*"),
+						id:      TargetStepID,
+					}},
+					errors: 1,
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.fields.settings,
+			}
+			if got := prc.checkPRTargetBranch(); !reflect.DeepEqual(got, tt.want(tt.fields.settings)) {
+				t.Errorf("PullRequestChecker.CheckPRTargetBranch() = %v, want %v", got, tt.want(tt.fields.settings))
+			}
+		})
+	}
+}
+
+func TestPullRequestChecker_Report(t *testing.T) {
+	tests := []struct {
+		name    string
+		checker PullRequestChecker
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "ReportSuccess",
+			checker: PullRequestChecker{steps: []Step{
+				{status: Success, message: PrefixSuccesMsg, id: PrefixStepID},
+				{status: Skip, message: RegexpSkipMsg, id: RegexpStepID},
+			}},
+			want: "✅ step=prefix message=prefixes check passed\n🦘 step=regexp message=" + strings.ToLower(RegexpSkipMsg) + "\n",
+		},
+		{
+			name: "ReportErrors",
+			checker: PullRequestChecker{
+				steps:  []Step{{status: Err, message: "PR title is wrong", id: PrefixStepID}},
+				errors: 1,
+			},
+			want:    "❌ step=prefix message=pr title is wrong\n",
+			wantErr: true,
+		},
+		{
+			name: "ReportDryRun",
+			checker: PullRequestChecker{
+				steps:    []Step{{status: Err, message: "PR title is wrong", id: PrefixStepID}},
+				errors:   1,
+				settings: config.Settings{DryRun: true},
+			},
+			want: "❌ step=prefix message=pr title is wrong\nDry run, found 1 errors\n",
+		},
+		{
+			name: "ReportExit",
+			checker: PullRequestChecker{
+				steps: []Step{
+					{status: Skip, message: "skip label found", id: LabelsStepID, exit: true},
+					{status: Err, message: "not reported", id: PrefixStepID},
+				},
+				errors: 1,
+			},
+			want: "🦘 step=labels message=skip label found\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := tt.checker.report(&out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PullRequestChecker.report() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if out.String() != tt.want {
+				t.Errorf("PullRequestChecker.report() wrote %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/config"
+	"github.com/nyambati/drone-pr-checker/internal/github"
+)
+
+func (prc *PullRequestChecker) checkPRReviews() *PullRequestChecker {
+
+	if prc.settings.MinApprovals <= 0 {
+		prc.steps = append(prc.steps, Step{status: Skip, message: ReviewsSkipMsg, id: ReviewsStepID})
+		return prc
+	}
+
+	pr, err := prc.github.GetPullRequest(prc.settings.Owner, prc.settings.Repo, prc.settings.PullRequest)
+	if err != nil {
+		return prc.githubError(ReviewsStepID, err)
+	}
+
+	reviews, err := prc.github.ListReviews(prc.settings.Owner, prc.settings.Repo, prc.settings.PullRequest)
+	if err != nil {
+		return prc.githubError(ReviewsStepID, err)
+	}
+
+	latest := github.LatestReviews(reviews)
+	logins := []string{}
+	for login := range latest {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+
+	org, team, _ := strings.Cut(prc.settings.ApproverTeam, "/")
+	problems := []string{}
+	approvals, stale, teamApprovals := 0, 0, 0
+
+	for _, login := range logins {
+		review := latest[login]
+		switch review.GetState() {
+		case "CHANGES_REQUESTED":
+			problems = append(problems, fmt.Sprintf("changes requested by %s", login))
+		case "APPROVED":
+			if prc.settings.DismissStale && review.GetCommitID() != pr.GetHead().GetSHA() {
+				stale++
+				continue
+			}
+			approvals++
+			if prc.settings.ApproverTeam == "" {
+				continue
+			}
+			member, err := prc.github.IsTeamMember(org, team, login)
+			if err != nil {
+				return prc.githubError(ReviewsStepID, err)
+			}
+			if member {
+				teamApprovals++
+				if prc.settings.ApproverTeamMode == config.ApproverTeamDouble {
+					approvals++
+				}
+			}
+		}
+	}
+
+	if approvals < prc.settings.MinApprovals {
+		problem := fmt.Sprintf("%d of %d required approvals", approvals, prc.settings.MinApprovals)
+		if stale > 0 {
+			problem += fmt.Sprintf(" (%d approvals predate the latest push)", stale)
+		}
+		problems = append(problems, problem)
+	}
+
+	if prc.settings.ApproverTeamMode == config.ApproverTeamMandatory && prc.settings.ApproverTeam != "" && teamApprovals == 0 {
+		problems = append(problems, fmt.Sprintf("no approval from %s", prc.settings.ApproverTeam))
+	}
+
+	if len(problems) > 0 {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(ReviewsErrMsg, strings.Join(problems, ", ")),
+				id:      ReviewsStepID,
+			},
+		)
+		prc.errors++
+		return prc
+	}
+
+	prc.steps = append(prc.steps, Step{status: Success, message: ReviewsSuccesMsg, id: ReviewsStepID})
+	return prc
+}
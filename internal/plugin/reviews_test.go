@@ -0,0 +1,91 @@
+package plugin
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+func TestPullRequestChecker_CheckPRReviews(t *testing.T) {
+	review := func(login string, state string, commit string) *github.PullRequestReview {
+		return &github.PullRequestReview{
+			User:     &github.User{Login: github.String(login)},
+			State:    github.String(state),
+			CommitID: github.String(commit),
+		}
+	}
+	head := &github.PullRequestBranch{SHA: github.String("head")}
+	teams := map[string][]string{"org/leads": {"lead"}}
+
+	tests := []struct {
+		name     string
+		settings config.Settings
+		reviews  []*github.PullRequestReview
+		want     []Step
+		errors   int
+	}{
+		{
+			name:     "CheckPRReviewsDisabled",
+			settings: config.Settings{},
+			want:     []Step{{status: Skip, message: ReviewsSkipMsg, id: ReviewsStepID}},
+		},
+		{
+			name:     "CheckPRReviewsEnoughApprovals",
+			settings: config.Settings{MinApprovals: 2},
+			reviews: []*github.PullRequestReview{
+				review("alice", "CHANGES_REQUESTED", "old"),
+				review("alice", "APPROVED", "head"),
+				review("bob", "APPROVED", "old"),
+				review("carol", "COMMENTED", "head"),
+			},
+			want: []Step{{status: Success, message: ReviewsSuccesMsg, id: ReviewsStepID}},
+		},
+		{
+			name:     "CheckPRReviewsStaleApprovalsAndChangesRequested",
+			settings: config.Settings{MinApprovals: 2, DismissStale: true},
+			reviews: []*github.PullRequestReview{
+				review("alice", "APPROVED", "head"),
+				review("bob", "APPROVED", "old"),
+				review("carol", "CHANGES_REQUESTED", "head"),
+			},
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(ReviewsErrMsg, "changes requested by carol, 1 of 2 required approvals (1 approvals predate the latest push)"),
+				id:      ReviewsStepID,
+			}},
+			errors: 1,
+		},
+		{
+			name:     "CheckPRReviewsTeamCountsDouble",
+			settings: config.Settings{MinApprovals: 2, ApproverTeam: "org/leads", ApproverTeamMode: config.ApproverTeamDouble},
+			reviews:  []*github.PullRequestReview{review("lead", "APPROVED", "head")},
+			want:     []Step{{status: Success, message: ReviewsSuccesMsg, id: ReviewsStepID}},
+		},
+		{
+			name:     "CheckPRReviewsTeamMandatory",
+			settings: config.Settings{MinApprovals: 1, ApproverTeam: "org/leads", ApproverTeamMode: config.ApproverTeamMandatory},
+			reviews:  []*github.PullRequestReview{review("alice", "APPROVED", "head")},
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(ReviewsErrMsg, "no approval from org/leads"),
+				id:      ReviewsStepID,
+			}},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.settings,
+				github:   &TestGithubClient{head: head, reviews: tt.reviews, teams: teams},
+			}
+			got := prc.checkPRReviews()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors {
+				t.Errorf("PullRequestChecker.CheckPRReviews() = %v (%d errors), want %v (%d errors)", got.steps, got.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package plugin
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nyambati/drone-pr-checker/internal/config"
+	"github.com/nyambati/drone-pr-checker/internal/glob"
+)
+
+// checkPRRules evaluates the path scoped rules against the files changed by
+// the pull request and applies the matching ones: checklist sections become
+// required, labels are added to the required labels and checks are marked to
+// be skipped. It has to run before the checks it configures.
+func (prc *PullRequestChecker) checkPRRules() *PullRequestChecker {
+
+	if len(prc.settings.Rules) == 0 {
+		prc.steps = append(prc.steps, Step{status: Skip, message: RulesSkipMsg, id: RulesStepID})
+		return prc
+	}
+
+	files, err := prc.github.ListFiles(
+		prc.settings.Owner,
+		prc.settings.Repo,
+		prc.settings.PullRequest,
+	)
+
+	if err != nil {
+		return prc.githubError(RulesStepID, err)
+	}
+
+	changed := []string{}
+	for _, file := range files {
+		changed = append(changed, file.GetFilename())
+	}
+
+	applied := []string{}
+
+	for _, rule := range prc.settings.Rules {
+		if !ruleMatches(rule, changed) {
+			continue
+		}
+		applied = append(applied, strings.Join(rule.Paths, "|"))
+		prc.applyRule(rule)
+	}
+
+	if len(applied) == 0 {
+		prc.steps = append(prc.steps, Step{status: Success, message: RulesNoneMsg, id: RulesStepID})
+		return prc
+	}
+
+	prc.steps = append(
+		prc.steps,
+		Step{status: Success, message: fmt.Sprintf(RulesSuccesMsg, strings.Join(applied, ", ")), id: RulesStepID},
+	)
+	return prc
+}
+
+func ruleMatches(rule config.Rule, files []string) bool {
+	if len(files) == 0 {
+		return false
+	}
+	for _, file := range files {
+		matched := glob.MatchAny(rule.Paths, file)
+		if matched && !rule.Only {
+			return true
+		}
+		if !matched && rule.Only {
+			return false
+		}
+	}
+	return rule.Only
+}
+
+func (prc *PullRequestChecker) applyRule(rule config.Rule) {
+	for _, title := range rule.Checklists {
+		index := slices.IndexFunc(prc.settings.Checklists, func(checklist config.Checklist) bool {
+			return strings.EqualFold(checklist.Title, title)
+		})
+		if index < 0 {
+			prc.settings.Checklists = append(prc.settings.Checklists, config.Checklist{Title: title, Required: true})
+			continue
+		}
+		// Copy before modifying, the slice is shared with the caller's settings.
+		prc.settings.Checklists = slices.Clone(prc.settings.Checklists)
+		prc.settings.Checklists[index].Required = true
+	}
+
+	if len(rule.Labels) > 0 {
+		// Copy before appending, the slice is shared with the caller's settings.
+		prc.settings.RequiredLabels = append(slices.Clone(prc.settings.RequiredLabels), rule.Labels...)
+	}
+
+	for _, id := range rule.Skip {
+		if prc.skip == nil {
+			prc.skip = map[string]bool{}
+		}
+		prc.skip[id] = true
+	}
+}
+
+func (prc *PullRequestChecker) checkPRRequiredLabels() *PullRequestChecker {
+
+	if len(prc.settings.RequiredLabels) == 0 {
+		prc.steps = append(prc.steps, Step{status: Skip, message: RequiredLabelsSkipMsg, id: RequiredLabelsStepID})
+		return prc
+	}
+
+	pr, err := prc.github.GetPullRequest(
+		prc.settings.Owner,
+		prc.settings.Repo,
+		prc.settings.PullRequest,
+	)
+
+	if err != nil {
+		return prc.githubError(RequiredLabelsStepID, err)
+	}
+
+	labels := []string{}
+
+	for _, label := range pr.Labels {
+		labels = append(labels, label.GetName())
+	}
+
+	missing := []string{}
+
+	for _, label := range uniq(prc.settings.RequiredLabels) {
+		if !slices.Contains(labels, label) {
+			missing = append(missing, label)
+		}
+	}
+
+	if len(missing) > 0 {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(RequiredLabelsErrMsg, strings.Join(missing, ", ")),
+				id:      RequiredLabelsStepID,
+			},
+		)
+		prc.errors++
+		return prc
+	}
+
+	prc.steps = append(prc.steps, Step{status: Success, message: RequiredLabelsSuccesMsg, id: RequiredLabelsStepID})
+	return prc
+}
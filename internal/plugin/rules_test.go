@@ -0,0 +1,110 @@
+package plugin
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+func TestPullRequestChecker_Rules(t *testing.T) {
+	rules := []config.Rule{
+		{Paths: []string{"infra/**"}, Checklists: []string{"## Security review"}, Labels: []string{"infra"}},
+		{Paths: []string{"docs/**", "**/*.md"}, Only: true, Skip: []string{CommitsStepID}},
+	}
+	files := func(names ...string) []*github.CommitFile {
+		files := []*github.CommitFile{}
+		for _, name := range names {
+			files = append(files, &github.CommitFile{Filename: github.String(name)})
+		}
+		return files
+	}
+	wip := []*github.RepositoryCommit{{
+		SHA:    github.String("1111111aaaa"),
+		Commit: &github.Commit{Message: github.String("WIP")},
+	}}
+
+	tests := []struct {
+		name   string
+		client *TestGithubClient
+		want   []Step
+		errors int
+	}{
+		{
+			name:   "RulesInfraChange",
+			client: &TestGithubClient{files: files("infra/main.tf", "README.md"), body: github.String("## Summary"), commits: wip},
+			want: []Step{
+				{status: Success, message: fmt.Sprintf(RulesSuccesMsg, "infra/**"), id: RulesStepID},
+				{status: Err, message: fmt.Sprintf(RequiredLabelsErrMsg, "infra"), id: RequiredLabelsStepID},
+				{status: Err, message: fmt.Sprintf(ChecklistRequiredMsg, "## Security review"), id: "checklist[## Security review]"},
+				{status: Err, message: fmt.Sprintf(CommitsErrMsg, 1, `1111111 "WIP" should be squashed`), id: CommitsStepID},
+			},
+			errors: 3,
+		},
+		{
+			name: "RulesInfraChangeCompliant",
+			client: &TestGithubClient{
+				files:  files("infra/main.tf"),
+				labels: []*github.Label{{Name: github.String("infra")}},
+				body:   github.String("## Security review\n- [x] Reviewed"),
+			},
+			want: []Step{
+				{status: Success, message: fmt.Sprintf(RulesSuccesMsg, "infra/**"), id: RulesStepID},
+				{status: Success, message: RequiredLabelsSuccesMsg, id: RequiredLabelsStepID},
+				{status: Success, message: ChecklistSuccesMsg, id: "checklist[## Security review]"},
+				{status: Success, message: CommitsSuccesMsg, id: CommitsStepID},
+			},
+		},
+		{
+			name:   "RulesDocsOnlyChange",
+			client: &TestGithubClient{files: files("docs/guide.md", "README.md"), commits: wip},
+			want: []Step{
+				{status: Success, message: fmt.Sprintf(RulesSuccesMsg, "docs/**|**/*.md"), id: RulesStepID},
+				{status: Skip, message: RequiredLabelsSkipMsg, id: RequiredLabelsStepID},
+				{status: Skip, message: ChecklistSkipMsg, id: ChecklistStepID},
+				{status: Skip, message: RulesSkippedMsg, id: CommitsStepID},
+			},
+		},
+		{
+			name:   "RulesMixedChange",
+			client: &TestGithubClient{files: files("docs/guide.md", "main.go"), commits: wip},
+			want: []Step{
+				{status: Success, message: RulesNoneMsg, id: RulesStepID},
+				{status: Skip, message: RequiredLabelsSkipMsg, id: RequiredLabelsStepID},
+				{status: Skip, message: ChecklistSkipMsg, id: ChecklistStepID},
+				{status: Err, message: fmt.Sprintf(CommitsErrMsg, 1, `1111111 "WIP" should be squashed`), id: CommitsStepID},
+			},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: config.Settings{Rules: rules, CommitHygiene: true},
+				github:   tt.client,
+			}
+			got := []Step{}
+			for _, step := range prc.run().steps {
+				switch step.id {
+				case RulesStepID, RequiredLabelsStepID, CommitsStepID, ChecklistStepID, "checklist[## Security review]":
+					got = append(got, step)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) || prc.errors != tt.errors {
+				t.Errorf("PullRequestChecker.run() = %v (%d errors), want %v (%d errors)", got, prc.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
+
+func TestSkippableChecks(t *testing.T) {
+	ids := []string{}
+	for _, check := range (&PullRequestChecker{}).checks() {
+		ids = append(ids, check.id)
+	}
+	if !reflect.DeepEqual(ids, config.SkippableChecks) {
+		t.Errorf("config.SkippableChecks = %q, want the check ids %q", config.SkippableChecks, ids)
+	}
+}
@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/nyambati/drone-pr-checker/internal/markdown"
+)
+
+// template reads the repository pull request template from the workspace
+// and reports whether it exists. A missing template is not an error, it
+// simply yields an empty document.
+func (prc *PullRequestChecker) template() (markdown.Document, bool, error) {
+	if prc.settings.Template == "" {
+		return markdown.Document{}, false, nil
+	}
+	content, err := os.ReadFile(prc.settings.Template)
+	if errors.Is(err, fs.ErrNotExist) {
+		return markdown.Document{}, false, nil
+	}
+	if err != nil {
+		return markdown.Document{}, false, err
+	}
+	return markdown.Parse(string(content)), true, nil
+}
+
+// sectionContent returns the text an author actually wrote in a section:
+// HTML comments and lines copied verbatim from the template are dropped.
+func sectionContent(section markdown.Section, placeholder markdown.Section) string {
+	untouched := map[string]bool{}
+	for _, line := range strings.Split(markdown.StripComments(placeholder.Text()), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			untouched[line] = true
+		}
+	}
+
+	content := []string{}
+	for _, line := range strings.Split(markdown.StripComments(section.Text()), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !untouched[line] {
+			content = append(content, line)
+		}
+	}
+	return strings.Join(content, "\n")
+}
+
+func (prc *PullRequestChecker) checkPRSections() *PullRequestChecker {
+
+	if len(prc.settings.RequiredSections) == 0 {
+		prc.steps = append(prc.steps, Step{status: Skip, message: SectionsSkipMsg, id: SectionsStepID})
+		return prc
+	}
+
+	pr, err := prc.github.GetPullRequest(
+		prc.settings.Owner,
+		prc.settings.Repo,
+		prc.settings.PullRequest,
+	)
+
+	if err != nil {
+		return prc.githubError(SectionsStepID, err)
+	}
+
+	template, _, err := prc.template()
+	if err != nil {
+		prc.steps = append(prc.steps, Step{status: Err, message: err.Error(), id: SectionsStepID})
+		prc.errors++
+		return prc
+	}
+
+	body := markdown.Parse(pr.GetBody())
+	problems := []string{}
+
+	for _, heading := range prc.settings.RequiredSections {
+		section, found := body.Find(heading)
+		if !found {
+			problems = append(problems, fmt.Sprintf("%q is missing", heading))
+			continue
+		}
+
+		placeholder, _ := template.Find(heading)
+		content := strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, sectionContent(section, placeholder))
+
+		if len([]rune(content)) < prc.settings.SectionMinLength {
+			problems = append(problems, fmt.Sprintf("%q has not been filled in", heading))
+		}
+	}
+
+	if len(problems) > 0 {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(SectionsErrMsg, strings.Join(problems, ", ")),
+				id:      SectionsStepID,
+			},
+		)
+		prc.errors++
+		return prc
+	}
+
+	prc.steps = append(prc.steps, Step{status: Success, message: SectionsSuccesMsg, id: SectionsStepID})
+	return prc
+}
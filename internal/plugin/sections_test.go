@@ -0,0 +1,98 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+func TestPullRequestChecker_CheckPRSections(t *testing.T) {
+	template := filepath.Join(t.TempDir(), "pull_request_template.md")
+	err := os.WriteFile(template, []byte(`## Summary
+<!-- What does this PR change and why? -->
+
+## Testing
+Describe how you tested this change.
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filled := "## Summary\r\nAdds the sections check.\r\n\r\n## Testing\r\nRan go test ./... locally."
+	untouched := `## Summary
+<!-- What does this PR change and why? -->
+
+## Testing
+Describe how you tested this change.
+`
+
+	tests := []struct {
+		name     string
+		settings config.Settings
+		body     string
+		want     []Step
+		errors   int
+	}{
+		{
+			name: "CheckPRSectionsDisabled",
+			body: untouched,
+			want: []Step{{status: Skip, message: SectionsSkipMsg, id: SectionsStepID}},
+		},
+		{
+			name:     "CheckPRSectionsFilled",
+			settings: config.Settings{RequiredSections: []string{"## Summary", "## Testing"}, SectionMinLength: 10, Template: template},
+			body:     filled,
+			want:     []Step{{status: Success, message: SectionsSuccesMsg, id: SectionsStepID}},
+		},
+		{
+			name:     "CheckPRSectionsUntouchedTemplate",
+			settings: config.Settings{RequiredSections: []string{"## Summary", "## Testing"}, SectionMinLength: 10, Template: template},
+			body:     untouched,
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(SectionsErrMsg, `"## Summary" has not been filled in, "## Testing" has not been filled in`),
+				id:      SectionsStepID,
+			}},
+			errors: 1,
+		},
+		{
+			name:     "CheckPRSectionsMissingSectionWithoutTemplate",
+			settings: config.Settings{RequiredSections: []string{"## Summary", "## Risks"}, SectionMinLength: 10},
+			body:     filled,
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(SectionsErrMsg, `"## Risks" is missing`),
+				id:      SectionsStepID,
+			}},
+			errors: 1,
+		},
+		{
+			name:     "CheckPRSectionsTooShort",
+			settings: config.Settings{RequiredSections: []string{"## Testing"}, SectionMinLength: 10, Template: template},
+			body:     "## Testing\nn/a",
+			want: []Step{{
+				status:  Err,
+				message: fmt.Sprintf(SectionsErrMsg, `"## Testing" has not been filled in`),
+				id:      SectionsStepID,
+			}},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.settings,
+				github:   &TestGithubClient{body: github.String(tt.body)},
+			}
+			got := prc.checkPRSections()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors {
+				t.Errorf("PullRequestChecker.CheckPRSections() = %v (%d errors), want %v (%d errors)", got.steps, got.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
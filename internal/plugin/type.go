@@ -0,0 +1,71 @@
+package plugin
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// titleTypeRe captures the type of "type(scope)!: subject" titles.
+var titleTypeRe = regexp.MustCompile(`^\s*([A-Za-z]+)(?:\([^)]*\))?!?:`)
+
+func (prc *PullRequestChecker) checkPRType() *PullRequestChecker {
+
+	if len(prc.settings.Types) == 0 {
+		prc.steps = append(prc.steps, Step{status: Skip, message: TypeSkipMsg, id: TypeStepID})
+		return prc
+	}
+
+	match := titleTypeRe.FindStringSubmatch(prc.settings.Title)
+	if match == nil {
+		prc.steps = append(prc.steps, Step{status: Err, message: TypeMissingMsg, id: TypeStepID})
+		prc.errors++
+		return prc
+	}
+
+	prType := strings.ToLower(match[1])
+	if !slices.ContainsFunc(prc.settings.Types, func(allowed string) bool { return strings.EqualFold(allowed, prType) }) {
+		prc.steps = append(
+			prc.steps,
+			Step{
+				status:  Err,
+				message: fmt.Sprintf(TypeErrMsg, prType, strings.Join(prc.settings.Types, ", ")),
+				id:      TypeStepID,
+			},
+		)
+		prc.errors++
+		return prc
+	}
+
+	label, mapped := prc.settings.TypeLabels[prType]
+	if !mapped {
+		prc.steps = append(prc.steps, Step{status: Success, message: TypeSuccesMsg, id: TypeStepID})
+		return prc
+	}
+
+	pr, err := prc.github.GetPullRequest(
+		prc.settings.Owner,
+		prc.settings.Repo,
+		prc.settings.PullRequest,
+	)
+
+	if err != nil {
+		return prc.githubError(TypeStepID, err)
+	}
+
+	labels := []string{}
+
+	for _, l := range pr.Labels {
+		labels = append(labels, l.GetName())
+	}
+
+	if !slices.Contains(labels, label) {
+		prc.steps = append(prc.steps, Step{status: Err, message: fmt.Sprintf(TypeLabelMsg, prType, label), id: TypeStepID})
+		prc.errors++
+		return prc
+	}
+
+	prc.steps = append(prc.steps, Step{status: Success, message: TypeSuccesMsg, id: TypeStepID})
+	return prc
+}
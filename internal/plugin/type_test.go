@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+	"github.com/nyambati/drone-pr-checker/internal/config"
+)
+
+func TestPullRequestChecker_CheckPRType(t *testing.T) {
+	types := []string{"feat", "fix", "docs"}
+	typeLabels := map[string]string{"feat": "enhancement", "fix": "bug"}
+
+	tests := []struct {
+		name     string
+		settings config.Settings
+		labels   []*github.Label
+		err      error
+		want     []Step
+		errors   int
+	}{
+		{
+			name:     "CheckPRTypeDisabled",
+			settings: config.Settings{Title: "anything goes"},
+			want:     []Step{{status: Skip, message: TypeSkipMsg, id: TypeStepID}},
+		},
+		{
+			name:     "CheckPRTypeMissing",
+			settings: config.Settings{Types: types, Title: "add a new feature"},
+			want:     []Step{{status: Err, message: TypeMissingMsg, id: TypeStepID}},
+			errors:   1,
+		},
+		{
+			name:     "CheckPRTypeNotAllowed",
+			settings: config.Settings{Types: types, Title: "chore(deps): bump go-github"},
+			want:     []Step{{status: Err, message: fmt.Sprintf(TypeErrMsg, "chore", "feat, fix, docs"), id: TypeStepID}},
+			errors:   1,
+		},
+		{
+			name:     "CheckPRTypeUnmapped",
+			settings: config.Settings{Types: types, TypeLabels: typeLabels, Title: "docs: explain presets"},
+			want:     []Step{{status: Success, message: TypeSuccesMsg, id: TypeStepID}},
+		},
+		{
+			name:     "CheckPRTypeMissingLabel",
+			settings: config.Settings{Types: types, TypeLabels: typeLabels, Title: "Feat(api)!: drop v1 endpoints"},
+			labels:   []*github.Label{{Name: github.String("bug")}},
+			want:     []Step{{status: Err, message: fmt.Sprintf(TypeLabelMsg, "feat", "enhancement"), id: TypeStepID}},
+			errors:   1,
+		},
+		{
+			name:     "CheckPRTypeLabelled",
+			settings: config.Settings{Types: types, TypeLabels: typeLabels, Title: "fix: handle empty bodies"},
+			labels:   []*github.Label{{Name: github.String("bug")}},
+			want:     []Step{{status: Success, message: TypeSuccesMsg, id: TypeStepID}},
+		},
+		{
+			name:     "CheckPRTypeGitHubError",
+			settings: config.Settings{Types: types, TypeLabels: typeLabels, Title: "fix: handle empty bodies"},
+			err:      errors.New("Error"),
+			want:     []Step{{status: Err, message: "Error", id: TypeStepID}},
+			errors:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prc := &PullRequestChecker{
+				settings: tt.settings,
+				github:   &TestGithubClient{labels: tt.labels, err: tt.err},
+			}
+			got := prc.checkPRType()
+			if !reflect.DeepEqual(got.steps, tt.want) || got.errors != tt.errors {
+				t.Errorf("PullRequestChecker.CheckPRType() = %v (%d errors), want %v (%d errors)", got.steps, got.errors, tt.want, tt.errors)
+			}
+		})
+	}
+}
@@ -1,11 +1,14 @@
 package plugin
 
+import "io"
+
 type State int
 
 const (
 	Success State = iota
 	Err
 	Skip
+	Warn
 )
 
 type Step struct {
@@ -15,8 +18,13 @@ type Step struct {
 	exit    bool
 }
 
+type check struct {
+	id  string
+	run func() *PullRequestChecker
+}
+
 type PluginInterface interface {
-	Report()
+	Report(w io.Writer) error
 }
 
 const (
@@ -34,6 +42,132 @@ const (
 	RegexpSuccesMsg    = "Regular expression check passed"
 	ChecklistStepID    = "checklist"
 	ChecklistSkipMsg   = "Checklist checks disabled"
-	ChecklistErrMsg    = "Found %d unchecked checklist items"
+	ChecklistErrMsg    = "Found %d unchecked checklist items: %s"
 	ChecklistSuccesMsg = "Checklist check passed"
 )
+
+const (
+	ChecklistMissingMsg  = "Checklist section %q from the PR template is missing"
+	ChecklistRemovedMsg  = "Found %d checklist items removed or reworded from the PR template: %s"
+	ChecklistRequiredMsg = "Required checklist section %q is missing"
+)
+
+const (
+	ChecklistTemplateErrMsg = "Checklist template check needs the PR template %q, which does not exist"
+)
+
+const (
+	SignaturesStepID    = "signatures"
+	SignaturesSkipMsg   = "Commit signature checks disabled"
+	SignaturesErrMsg    = "Found %d unverified commits: %s"
+	SignaturesSuccesMsg = "All commits are signed and verified"
+)
+
+const (
+	CommitsStepID    = "commits"
+	CommitsSkipMsg   = "Commit hygiene checks disabled"
+	CommitsErrMsg    = "Found %d commit hygiene issues: %s"
+	CommitsSuccesMsg = "Commit hygiene check passed"
+)
+
+const (
+	BranchStepID    = "branch"
+	BranchSkipMsg   = "No branch naming pattern to check"
+	BranchErrMsg    = "Branch %q does not match the branch naming pattern"
+	BranchSuccesMsg = "Branch naming check passed"
+	TargetStepID    = "target"
+	TargetSkipMsg   = "No target branch rules to check"
+	TargetErrMsg    = "Branch %q is not allowed to target %q (allowed: %s)"
+	TargetSuccesMsg = "Target branch check passed"
+)
+
+const (
+	IssueStepID    = "issue"
+	IssueSkipMsg   = "Issue reference checks disabled"
+	IssueErrMsg    = "No issue reference found in PR title, body or branch name"
+	IssueInvalid   = "No valid issue reference found: %s"
+	IssueSuccesMsg = "Issue reference check passed"
+)
+
+const (
+	SectionsStepID    = "sections"
+	SectionsSkipMsg   = "No required description sections to check"
+	SectionsErrMsg    = "PR description is incomplete: %s"
+	SectionsSuccesMsg = "Description sections check passed"
+)
+
+const (
+	RulesStepID             = "rules"
+	RulesSkipMsg            = "No path rules to check"
+	RulesNoneMsg            = "No path rules matched the changed files"
+	RulesSuccesMsg          = "Applied path rules for %s"
+	RulesSkippedMsg         = "Skipped by path rules"
+	RequiredLabelsStepID    = "required-labels"
+	RequiredLabelsSkipMsg   = "No required labels to check"
+	RequiredLabelsErrMsg    = "PR is missing required labels: %s"
+	RequiredLabelsSuccesMsg = "Required labels check passed"
+)
+
+const (
+	CodeownersStepID    = "codeowners"
+	CodeownersSkipMsg   = "Code owner approval checks disabled"
+	CodeownersNoFileMsg = "No CODEOWNERS file found"
+	CodeownersErrMsg    = "Found %d paths without code owner approval: %s"
+	CodeownersSuccesMsg = "Code owner approval check passed"
+)
+
+const (
+	ReviewsStepID    = "reviews"
+	ReviewsSkipMsg   = "Review checks disabled"
+	ReviewsErrMsg    = "Review requirements not met: %s"
+	ReviewsSuccesMsg = "Review check passed"
+)
+
+const (
+	DraftStepID    = "draft"
+	DraftSkipMsg   = "Draft checks disabled"
+	DraftExitMsg   = "Skipping checks for draft PR"
+	DraftErrMsg    = "PR is a draft"
+	DraftWarnMsg   = "PR is a draft, failures are reported as warnings"
+	DraftSuccesMsg = "PR is not a draft"
+)
+
+const (
+	MergeableStepID     = "mergeable"
+	MergeableSkipMsg    = "Mergeability checks disabled"
+	MergeableUnknownMsg = "GitHub has not computed mergeability after %d attempts"
+	MergeableErrMsg     = "PR cannot be merged cleanly: %s"
+	MergeableSuccesMsg  = "Mergeability check passed"
+)
+
+const (
+	FreezeStepID      = "freeze"
+	FreezeSkipMsg     = "No merge freeze windows to check"
+	FreezeErrMsg      = "Branch %s is frozen (%s)"
+	FreezeOverrideMsg = "Merge freeze (%s) overridden by label %s"
+	FreezeSuccesMsg   = "No merge freeze in effect"
+)
+
+const (
+	PatternErrMsg = "Invalid regular expression: %v"
+)
+
+const (
+	TypeStepID     = "type"
+	TypeSkipMsg    = "No PR types to check"
+	TypeMissingMsg = "PR title does not start with a type, e.g. \"feat: add a feature\""
+	TypeErrMsg     = "PR type %q is not one of %s"
+	TypeLabelMsg   = "PRs of type %q must carry the %q label"
+	TypeSuccesMsg  = "Type check passed"
+)
+
+const (
+	ChangelogStepID    = "changelog"
+	ChangelogSkipMsg   = "No changelog to check"
+	ChangelogErrMsg    = "PR does not add a changelog entry (%s)"
+	ChangelogSuccesMsg = "Changelog check passed"
+)
+
+const (
+	CodeownersEmailMsg = "Code owner approval check passed, %d paths owned only by email addresses were not checked: %s"
+)
@@ -0,0 +1,57 @@
+package tracker
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Tracker looks up issues in an external tracker such as Jira or Linear over
+// HTTP. The URL is a template in which "{key}" is replaced by the issue key,
+// e.g. https://jira.example.com/rest/api/2/issue/{key}, so it can just as
+// well point at a local stub or an internal proxy.
+type Tracker struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func (t *Tracker) IssueExists(key string) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.ReplaceAll(t.url, "{key}", url.PathEscape(key)), nil)
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if t.token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return true, nil
+	default:
+		return false, fmt.Errorf("issue tracker returned %s for %s", resp.Status, key)
+	}
+}
+
+func New(url string, token string, client *http.Client) TrackerInterface {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Tracker{
+		url:    url,
+		token:  token,
+		client: client,
+	}
+}
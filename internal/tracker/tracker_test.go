@@ -0,0 +1,50 @@
+package tracker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTracker_IssueExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/issue/ABC-1":
+			w.WriteHeader(http.StatusOK)
+		case "/issue/ABC-2":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		token   string
+		key     string
+		want    bool
+		wantErr bool
+	}{
+		{name: "IssueExistsFound", token: "secret", key: "ABC-1", want: true},
+		{name: "IssueExistsNotFound", token: "secret", key: "ABC-2", want: false},
+		{name: "IssueExistsServerError", token: "secret", key: "ABC-3", wantErr: true},
+		{name: "IssueExistsUnauthorized", key: "ABC-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := New(server.URL+"/issue/{key}", tt.token, server.Client())
+			got, err := tracker.IssueExists(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Tracker.IssueExists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Tracker.IssueExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
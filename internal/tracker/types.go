@@ -0,0 +1,5 @@
+package tracker
+
+type TrackerInterface interface {
+	IssueExists(key string) (bool, error)
+}
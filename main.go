@@ -1,23 +1,16 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"log"
+	"os"
 
-	"github.com/nyambati/drone-pr-checker/internal/config"
-	"github.com/nyambati/drone-pr-checker/internal/github"
-	"github.com/nyambati/drone-pr-checker/internal/plugin"
+	"github.com/nyambati/drone-pr-checker/internal/cli"
 )
 
 func main() {
-	config, err := config.New()
-
-	if err != nil {
+	if err := cli.Run(os.Args[1:], os.Stdout); err != nil && !errors.Is(err, flag.ErrHelp) {
 		log.Fatal(err)
 	}
-
-	plugin := plugin.New(
-		config.Settings,
-		github.New(config.Github.Token),
-	)
-	plugin.Report()
 }